@@ -64,3 +64,15 @@ func (this *Configuration) GetInt(key string) int {
 	}
 	return instance.settings.getInt(key)
 }
+
+func (this *Configuration) GetBool(key string) bool {
+	// If no configuration was loaded, use default of DEV
+	// TODO Change default environment to prod?
+
+	defaultEnv := "DEV"
+
+	if instance == nil {
+		GetConfiguration(defaultEnv)
+	}
+	return instance.settings.getBool(key)
+}
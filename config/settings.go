@@ -7,6 +7,7 @@ type settings struct {
 	CorsHeaders			string
 	clientIdLetters		string
 	clientIdLength		int
+	isGameSeedAllowed	bool  // Seed decides the whole deck order, so it is only taken from clients when debugging
 }
 
 func getSettings(env environment) *settings {
@@ -25,9 +26,11 @@ func getSettings(env environment) *settings {
 		return s
 	case DEV:
 		s.CorsOrigin = "*"
+		s.isGameSeedAllowed = true
 		return s
 	case TEST:
 		s.CorsOrigin = "*"
+		s.isGameSeedAllowed = true
 		return s
 	case STAGING:
 		s.CorsOrigin = "*"
@@ -50,6 +53,15 @@ func (this *settings) getString(key string) string {
 	}
 }
 
+func (this *settings) getBool(key string) bool {
+	switch key {
+	case "IsGameSeedAllowed":
+		return this.isGameSeedAllowed
+	default:
+		return false
+	}
+}
+
 func (this *settings) getInt(key string) int {
 	switch key {
 	case "ClientIdLength":
@@ -35,8 +35,7 @@ func (this *Board) AddDefendingCard(attackingCard *Card, defendingCard *Card, ow
 	// This only validates attacking card is on table and un defended
 
 	for _, cardOnBoard := range this.cardsOnBoard {
-		if cardOnBoard.attackingCard.Kind == attackingCard.Kind &&
-			cardOnBoard.attackingCard.Value == attackingCard.Value {
+		if cardOnBoard.attackingCard.IsSameCard(attackingCard) {
 			if cardOnBoard.defendingCard != nil {
 				return fmt.Errorf("%v is already defended with %v\n", cardOnBoard.attackingCard, cardOnBoard.defendingCard)
 			} else {
@@ -51,6 +50,15 @@ func (this *Board) AddDefendingCard(attackingCard *Card, defendingCard *Card, ow
 
 }
 
+func (this *Board) IsCardUndefended(attackingCard *Card) bool {
+	for _, undefendedCard := range this.peekUndefendedCards() {
+		if undefendedCard.IsSameCard(attackingCard) {
+			return true
+		}
+	}
+	return false
+}
+
 func (this *Board) CanCardBeAdded(card *Card) bool {
 	for _, currentCard := range this.cardsOnBoard {
 		if currentCard.attackingCard.Value == card.Value ||
@@ -62,6 +70,23 @@ func (this *Board) CanCardBeAdded(card *Card) bool {
 	return false
 }
 
+func (this *Board) CanCardBeTransferred(card *Card) bool {
+	// A transfer is only possible before any card is defended,
+	// and only with a card of the same value as the attacking cards
+
+	if this.IsEmpty() {
+		return false
+	}
+
+	for _, cardOnBoard := range this.cardsOnBoard {
+		if cardOnBoard.defendingCard != nil || cardOnBoard.attackingCard.Value != card.Value {
+			return false
+		}
+	}
+
+	return true
+}
+
 func (this *Board) PeekCards() []*Card {
 	// Returns all cards that are on the board
 	// Does NOT remove cards from board
@@ -76,11 +101,12 @@ func (this *Board) PeekCards() []*Card {
 }
 
 func (this *Board) PeekCardsOnBoard() []*CardOnBoard {
-	// Returns all cards on board
+	// Returns copies of all cards on board, so they do not change when cards are defended
 	// Does not remove cards from board
 	cards := make([]*CardOnBoard, 0)
 	for _, cardOnBoard := range this.cardsOnBoard {
-		cards = append(cards, cardOnBoard)
+		cardOnBoardCopy := *cardOnBoard
+		cards = append(cards, &cardOnBoardCopy)
 	}
 	return cards
 }
@@ -5,16 +5,33 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 
+// Jokers have their own value, higher than any other card
+const (
+	JokerValue     = 15
+	jokerValueCode = "X"
+)
+
+// Cards of every deck but the first have their deck index added to code, like 7C#1
+const deckIndexSeparator = "#"
+
 type Card struct {
 	Kind  Kind
 	Value uint
+	DeckIndex uint  // Tells apart identical cards when playing with more than one deck
 }
 
 func NewCard(kind Kind, value uint) (*Card, error) {
 	card := &Card{Kind: kind, Value: value}
+	if IsJokerKind(kind) {
+		if value != JokerValue {
+			return nil, errors.New("joker value incorrect")
+		}
+		return card, nil
+	}
 	if value < MinCardValue || value > MaxCardValue {
 		return nil, errors.New("card value incorrect")
 	}
@@ -27,9 +44,26 @@ func NewCard(kind Kind, value uint) (*Card, error) {
 }
 
 func NewCardByCode(code string) (*Card, error) {
+	// Card codes come from clients, so parsing errors are invalid card errors
+	card, err := parseCardCode(code)
+	if err != nil {
+		return nil, newGameError(ErrInvalidCard, "bad card code '%s': %s", code, err)
+	}
+	return card, nil
+}
+
+func parseCardCode(code string) (*Card, error) {
 	if code == "" {
 		return nil, errors.New("no card returning nil")
 	}
+
+	code, deckIndex, err := splitDeckIndexFromCode(code)
+	if err != nil { return nil, err }
+
+	if len(code) < 2 {
+		return nil, fmt.Errorf("bad card code: %s", code)
+	}
+
 	kindCode := code[len(code)-1]
 	valueCode := code[:len(code)-1]
 
@@ -42,11 +76,23 @@ func NewCardByCode(code string) (*Card, error) {
 	if newCard, err := NewCard(kind, value); err != nil {
 		return nil, err
 	} else {
+		newCard.DeckIndex = deckIndex
 		return newCard, nil
 	}
 }
 
 func CardToCode(card *Card) (string, error) {
+	code, err := cardToCodeWithoutDeckIndex(card)
+	if err != nil {
+		return "", err
+	}
+	if card.DeckIndex > 0 {
+		code = code + deckIndexSeparator + fmt.Sprint(card.DeckIndex)
+	}
+	return code, nil
+}
+
+func cardToCodeWithoutDeckIndex(card *Card) (string, error) {
 	valueCode, err := valueToCode(card.Value)
 	if err != nil {
 		return "", err
@@ -61,6 +107,13 @@ func CardToCode(card *Card) (string, error) {
 }
 
 func (this *Card) CanDefendCard(attackCard *Card, kozerKind *Kind) bool {
+	// Joker beats anything and nothing beats a joker
+	if attackCard.IsJoker() {
+		return false
+	} else if this.IsJoker() {
+		return true
+	}
+
 	if this.isSameSuit(attackCard) {
 		return this.Value > attackCard.Value
 	} else {
@@ -68,6 +121,14 @@ func (this *Card) CanDefendCard(attackCard *Card, kozerKind *Kind) bool {
 	}
 }
 
+func (this *Card) IsSameCard(card *Card) bool {
+	return this.Kind == card.Kind && this.Value == card.Value && this.DeckIndex == card.DeckIndex
+}
+
+func (this *Card) IsJoker() bool {
+	return IsJokerKind(this.Kind)
+}
+
 func valueToCode(value uint) (string, error) {
 	if value >= MinCardValue && value <= 10 {
 		return fmt.Sprint(value), nil
@@ -81,6 +142,8 @@ func valueToCode(value uint) (string, error) {
 			return "K", nil
 		case 14:
 			return "A", nil
+		case JokerValue:
+			return jokerValueCode, nil
 		default:
 			return "", errors.New("no such card value")
 		}
@@ -91,6 +154,23 @@ func (this *Card) isSameSuit(card *Card) bool {
 	return this.Kind == card.Kind
 }
 
+func splitDeckIndexFromCode(code string) (string, uint, error) {
+	// Returns card code without deck index, and the deck index
+	parts := strings.Split(code, deckIndexSeparator)
+	switch len(parts) {
+	case 1:
+		return code, 0, nil
+	case 2:
+		deckIndex, err := strconv.Atoi(parts[1])
+		if err != nil || deckIndex < 1 || parts[0] == "" {
+			return "", 0, fmt.Errorf("bad deck index in card code: %s", code)
+		}
+		return parts[0], uint(deckIndex), nil
+	default:
+		return "", 0, fmt.Errorf("bad card code: %s", code)
+	}
+}
+
 func getCardValueByCode(valueCode string) (uint, error) {
 	switch valueCode {
 	case "A":
@@ -101,6 +181,8 @@ func getCardValueByCode(valueCode string) (uint, error) {
 		return 12, nil
 	case "J":
 		return 11, nil
+	case jokerValueCode:
+		return JokerValue, nil
 	default:
 		if string(valueCode[0]) == "0" {
 			return 0, errors.New("value has 0 in the beginning")
@@ -123,28 +205,29 @@ func (this *Card) MarshalJSON() ([]byte, error) {
 }
 
 func (this *Card) UnmarshalJSON(data []byte) error {
-	code := data[1:len(data)-1]
-	kindCode := code[len(code)-1]
-	valueCode := code[:len(code)-1]
-
-	if kind, err := GetCardKindByCode(string(kindCode)); err != nil {
-		return err
-	} else {
-		this.Kind = kind
+	// Cards come from clients and stored positions, decoded like any other card code
+	var code string
+	if err := json.Unmarshal(data, &code); err != nil {
+		return newGameError(ErrInvalidCard, "card code must be a string: %s", err)
 	}
 
-	if value, err := getCardValueByCode(string(valueCode)); err != nil {
+	card, err := NewCardByCode(code)
+	if err != nil {
 		return err
-	} else {
-		this.Value = value
 	}
-
+	*this = *card
 	return nil
 }
 
 // Print override
 
 func (this *Card) String() string {
+	if this.IsJoker() && this.DeckIndex > 0 {
+		return fmt.Sprintf("%s (deck %d)", this.Kind, this.DeckIndex+1)
+	} else if this.IsJoker() {
+		return string(this.Kind)
+	}
+
 	var valueString string
 
 	switch int(this.Value) {
@@ -160,5 +243,8 @@ func (this *Card) String() string {
 			valueString = fmt.Sprint(this.Value)
 		}
 
+	if this.DeckIndex > 0 {
+		return fmt.Sprintf("%s of %s (deck %d)", valueString, this.Kind, this.DeckIndex+1)
+	}
 	return fmt.Sprintf("%s of %s", valueString, this.Kind)
 }
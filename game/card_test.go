@@ -0,0 +1,97 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestNewCardByCode(t *testing.T) {
+	tests := []struct {
+		code      string
+		value     uint
+		kind      Kind
+		deckIndex uint
+	}{
+		{code: "6C", value: 6, kind: Clubs},
+		{code: "10H", value: 10, kind: Hearts},
+		{code: "AS#1", value: 14, kind: Spades, deckIndex: 1},
+	}
+
+	for _, test := range tests {
+		card, err := NewCardByCode(test.code)
+		if err != nil {
+			t.Fatalf("%s: %s", test.code, err)
+		}
+		if card.Value != test.value || card.Kind != test.kind || card.DeckIndex != test.deckIndex {
+			t.Fatalf("%s parsed as %v of deck %d", test.code, card, card.DeckIndex)
+		}
+		if code, _ := CardToCode(card); code != test.code {
+			t.Fatalf("%s coded back as %s", test.code, code)
+		}
+	}
+}
+
+func TestNewCardByCodeInvalid(t *testing.T) {
+	for _, code := range []string{"", "7", "C", "7Z", "1C", "06C", "15C", "7C#0", "7C#x", "#1", "7C#1#2"} {
+		card, err := NewCardByCode(code)
+		if err == nil {
+			t.Fatalf("%q parsed as %v", code, card)
+		}
+		if !errors.Is(err, ErrInvalidCard) || GetErrorCode(err) != ErrInvalidCard.Code {
+			t.Fatalf("%q must be an invalid card error, got %v", code, err)
+		}
+	}
+}
+
+func TestCardJSON(t *testing.T) {
+	for _, code := range []string{"6C", "10H", "AS#1", "XR", "XB#1"} {
+		card := &Card{}
+		if err := json.Unmarshal([]byte(`"`+code+`"`), card); err != nil {
+			t.Fatalf("%s: %s", code, err)
+		}
+		data, err := json.Marshal(card)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `"`+code+`"` {
+			t.Fatalf("%s coded back as %s", code, data)
+		}
+	}
+}
+
+func TestCardJSONInvalid(t *testing.T) {
+	for _, data := range []string{`""`, `"C"`, `"7"`, `"XC"`, `"7R"`, `"15C"`, `"7C#0"`, `7`, `["7C"]`} {
+		card := &Card{}
+		err := json.Unmarshal([]byte(data), card)
+		if err == nil {
+			t.Fatalf("%s decoded as %v", data, card)
+		}
+		if !errors.Is(err, ErrInvalidCard) {
+			t.Fatalf("%s must be an invalid card error, got %v", data, err)
+		}
+	}
+}
+
+func TestJokerDefence(t *testing.T) {
+	kozerKind := Spades
+	tests := []struct {
+		defendingCode string
+		attackingCode string
+		canDefend     bool
+	}{
+		{defendingCode: "XR", attackingCode: "AS", canDefend: true},
+		{defendingCode: "XB", attackingCode: "7H", canDefend: true},
+		{defendingCode: "AS", attackingCode: "XR", canDefend: false},
+		{defendingCode: "XB", attackingCode: "XR", canDefend: false},
+		{defendingCode: "7S", attackingCode: "AH", canDefend: true},
+	}
+
+	for _, test := range tests {
+		defendingCard, _ := NewCardByCode(test.defendingCode)
+		attackingCard, _ := NewCardByCode(test.attackingCode)
+		if defendingCard.CanDefendCard(attackingCard, &kozerKind) != test.canDefend {
+			t.Errorf("%s defending %s: expected %v", defendingCard, attackingCard, test.canDefend)
+		}
+	}
+}
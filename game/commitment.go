@@ -0,0 +1,67 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Commit-reveal of the deck - a hash of the shuffled deck order is published before dealing,
+// and the order itself is revealed once the game is over, so players can check the deck was not changed.
+
+const deckSaltLength = 16
+
+func VerifyDeckCommitment(commitment string, deckOrder []string, salt string) bool {
+	// Deck order is card codes in dealing order (kozer last), as revealed when game is over
+	return commitment != "" && commitment == getDeckCommitment(deckOrder, salt)
+}
+
+func (this *Game) GetDeckCommitment() string {
+	return this.deckCommitment
+}
+
+func (this *Game) GetRevealedDeckOrder() []string {
+	// Revealed only once game is over
+	if !this.IsGameOver() {
+		return nil
+	}
+	return this.deckOrder
+}
+
+func (this *Game) GetRevealedDeckSalt() string {
+	// Revealed only once game is over
+	if !this.IsGameOver() {
+		return ""
+	}
+	return this.deckSalt
+}
+
+func (this *Game) commitToDeck() error {
+	// Must be called right after shuffling, before any card leaves the deck
+
+	deckOrder := make([]string, 0, len(this.deck.cards))
+	for _, card := range this.deck.cards {
+		code, err := CardToCode(card)
+		if err != nil {
+			return err
+		}
+		deckOrder = append(deckOrder, code)
+	}
+
+	// Salt does not come from the game seed, so it can not be guessed from it
+	saltBytes := make([]byte, deckSaltLength)
+	if _, err := rand.Read(saltBytes); err != nil {
+		return err
+	}
+
+	this.deckOrder = deckOrder
+	this.deckSalt = hex.EncodeToString(saltBytes)
+	this.deckCommitment = getDeckCommitment(this.deckOrder, this.deckSalt)
+	return nil
+}
+
+func getDeckCommitment(deckOrder []string, salt string) string {
+	hash := sha256.Sum256([]byte(salt + ":" + strings.Join(deckOrder, ",")))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,57 @@
+package game
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDeckCommitment(t *testing.T) {
+	options := NewDefaultGameOptions()
+	options.Seed = 3
+	game, err := NewGame(options, "a", "b", "c")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitment := game.GetDeckCommitment()
+	if commitment == "" {
+		t.Fatal("deck must be committed to when game is created")
+	}
+
+	// Order and salt are hidden until game is over
+	random := rand.New(rand.NewSource(3))
+	for numOfMoves := 0; !game.IsGameOver(); numOfMoves++ {
+		if game.GetRevealedDeckOrder() != nil || game.GetRevealedDeckSalt() != "" {
+			t.Fatalf("deck order revealed after %d moves, before game is over", numOfMoves)
+		}
+		if numOfMoves > 1000 {
+			t.Fatal("game is not over after 1000 moves")
+		}
+		playRandomMove(t, game, random)
+	}
+
+	deckOrder := game.GetRevealedDeckOrder()
+	salt := game.GetRevealedDeckSalt()
+	if len(deckOrder) != DefaultDeckSize || salt == "" {
+		t.Fatalf("expected deck order and salt to be revealed, got %v and %q", deckOrder, salt)
+	}
+	if kozerCode, _ := CardToCode(game.KozerCard); deckOrder[len(deckOrder)-1] != kozerCode {
+		t.Fatalf("kozer %s must be the last card of revealed order %v", kozerCode, deckOrder)
+	}
+	if game.GetDeckCommitment() != commitment {
+		t.Fatal("commitment changed during game")
+	}
+	if !VerifyDeckCommitment(commitment, deckOrder, salt) {
+		t.Fatal("revealed deck order does not match commitment")
+	}
+
+	// Any change of order or salt is caught
+	changedOrder := append([]string{}, deckOrder...)
+	changedOrder[0], changedOrder[1] = changedOrder[1], changedOrder[0]
+	if VerifyDeckCommitment(commitment, changedOrder, salt) {
+		t.Fatal("changed deck order must not match commitment")
+	}
+	if VerifyDeckCommitment(commitment, deckOrder, salt+"0") {
+		t.Fatal("changed salt must not match commitment")
+	}
+}
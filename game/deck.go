@@ -2,34 +2,55 @@ package game
 
 import (
 	"math/rand"
-	"time"
 )
 
 type Deck struct {
 	cards []*Card
 }
 
-func NewDeck() (*Deck, error) {
+func NewDeck(minCardValue uint, maxCardValue uint, withJokers bool, numOfDecks int) (*Deck, error) {
 	deck := Deck{}
 	deck.cards = make([]*Card, 0)
-	for v := MinCardValue; v <= MaxCardValue; v++ {
-		for _, kind := range Kinds {
-			card, err := NewCard(kind, uint(v))
-			if err == nil {
-				deck.cards = append(deck.cards, card)
-			} else { return nil, err}
+	for deckIndex := 0; deckIndex < numOfDecks; deckIndex++ {
+		for v := minCardValue; v <= maxCardValue; v++ {
+			for _, kind := range Kinds {
+				card, err := NewCard(kind, uint(v))
+				if err == nil {
+					card.DeckIndex = uint(deckIndex)
+					deck.cards = append(deck.cards, card)
+				} else { return nil, err}
+			}
+		}
+		if withJokers {
+			for _, kind := range JokerKinds {
+				card, err := NewCard(kind, JokerValue)
+				if err == nil {
+					card.DeckIndex = uint(deckIndex)
+					deck.cards = append(deck.cards, card)
+				} else { return nil, err}
+			}
 		}
 	}
 	return &deck, nil
 }
 
-func (this *Deck) Shuffle() {
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(this.cards), func(i, j int) {
+func (this *Deck) Shuffle(rng *rand.Rand) {
+	rng.Shuffle(len(this.cards), func(i, j int) {
 		this.cards[i], this.cards[j] = this.cards[j], this.cards[i]
 	})
 }
 
+func (this *Deck) MoveJokersFromBottom() {
+	// Last card decides kozer, so it can not be a joker
+
+	for i := 0; i < len(this.cards) && this.PeekLastCard().IsJoker(); i++ {
+		if !this.cards[i].IsJoker() {
+			last := len(this.cards) - 1
+			this.cards[i], this.cards[last] = this.cards[last], this.cards[i]
+		}
+	}
+}
+
 func (this *Deck) GetNextCard() *Card {
 	// Gets and removes card from deck
 
@@ -45,9 +66,20 @@ func (this *Deck) GetNextCard() *Card {
 func (this *Deck) PeekLastCard() *Card {
 	// Does not remove card from deck (used for kozer card)
 
+	if len(this.cards) == 0 {
+		return nil
+	}
 	return this.cards[len(this.cards)-1]
 }
 
+func (this *Deck) SwapLastCard(card *Card) *Card {
+	// Puts card instead of the last card (kozer card) and returns the replaced card
+
+	lastCard := this.PeekLastCard()
+	this.cards[len(this.cards)-1] = card
+	return lastCard
+}
+
 func (this *Deck) GetNumOfCardsLeft() int {
 	return len(this.cards)
 }
\ No newline at end of file
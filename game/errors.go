@@ -0,0 +1,65 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors of game moves, each with a stable code so clients do not need to match on messages.
+// Returned errors carry details in their message, and match their kind with errors.Is
+
+type GameError struct {
+	Code    string
+	message string
+}
+
+var (
+	ErrInvalidCard     = &GameError{"invalidCard", "card is not valid"}
+	ErrCardNotInHand   = &GameError{"cardNotInHand", "card is not in player's hand"}
+	ErrPlayerNotFound  = &GameError{"playerNotFound", "no such player exists"}
+	ErrNotPlaying      = &GameError{"notPlaying", "player is not playing"}
+	ErrNotYourTurn     = &GameError{"notYourTurn", "player can not make this move now"}
+	ErrWrongPhase      = &GameError{"wrongPhase", "move is not possible in current phase"}
+	ErrAlreadyDone     = &GameError{"alreadyDone", "player has already made this move"}
+	ErrAttackLimit     = &GameError{"attackLimit", "no more cards can be added to the attack"}
+	ErrCannotAdd       = &GameError{"cannotAdd", "card can not be added to the attack"}
+	ErrCannotBeat      = &GameError{"cannotBeat", "card can not beat the attacking card"}
+	ErrCannotTransfer  = &GameError{"cannotTransfer", "card can not be used to transfer"}
+	ErrNotAllowed      = &GameError{"notAllowed", "move is not allowed in this game"}
+	ErrInvalidOptions  = &GameError{"invalidOptions", "game options are not valid"}
+)
+
+func NewGameError(code string, message string) *GameError {
+	// Errors of whoever runs the games, like a server, coded the same way as game errors
+	return &GameError{Code: code, message: message}
+}
+
+func (this *GameError) Error() string {
+	return this.message
+}
+
+func GetErrorCode(err error) string {
+	// Empty for errors that are not game errors
+	var gameError *GameError
+	if errors.As(err, &gameError) {
+		return gameError.Code
+	}
+	return ""
+}
+
+type detailedGameError struct {
+	kind    *GameError
+	message string
+}
+
+func newGameError(kind *GameError, format string, args ...interface{}) error {
+	return &detailedGameError{kind: kind, message: fmt.Sprintf(format, args...)}
+}
+
+func (this *detailedGameError) Error() string {
+	return this.message
+}
+
+func (this *detailedGameError) Unwrap() error {
+	return this.kind
+}
@@ -0,0 +1,120 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Every state changing action of a game is recorded as an event.
+// Replaying the events with the game seed builds the exact same game, at any step.
+
+type EventType string
+
+const (
+	EventDeal        = EventType("deal")
+	EventAttack      = EventType("attack")
+	EventDefend      = EventType("defend")
+	EventTransfer    = EventType("transfer")
+	EventPass        = EventType("pass")
+	EventTake        = EventType("take")
+	EventBita        = EventType("bita")
+	EventPlayerLeft  = EventType("playerLeft")
+	EventKozerSwap   = EventType("kozerSwap")
+	EventRedeal      = EventType("redeal")
+	EventChallenge   = EventType("challenge")
+)
+
+type Event struct {
+	Type        EventType    `json:"type"`
+	Time        time.Time    `json:"time"`
+	PlayerName  string       `json:"playerName"`  // Empty for deal and bita
+	Cards       []*Card      `json:"cards"`  // Attack and transfer
+	Defences    []*Defence   `json:"defences"`  // Defend
+	Options     *GameOptions `json:"options"`  // Deal
+	PlayerNames []string     `json:"playerNames"`  // Deal
+	State       *GameState   `json:"state,omitempty"`  // Deal of a game built from a state
+}
+
+func Replay(seed int64, events []*Event) (*Game, error) {
+	// Pass only the first events to get the game at that step
+
+	if len(events) == 0 || events[0].Type != EventDeal || events[0].Options == nil {
+		return nil, errors.New("replay must start with a deal event")
+	}
+
+	options := *events[0].Options
+	options.Seed = seed
+	var game *Game
+	var err error
+	if events[0].State != nil {
+		state := *events[0].State
+		state.Options = &options
+		game, err = NewGameFromState(&state)
+	} else {
+		game, err = NewGame(&options, events[0].PlayerNames...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	game.events[0].Time = events[0].Time
+
+	for i, event := range events[1:] {
+		if err := game.applyEvent(event); err != nil {
+			return nil, fmt.Errorf("could not replay event %d (%s): %s", i+1, event.Type, err)
+		}
+		game.events[len(game.events)-1].Time = event.Time
+	}
+
+	return game, nil
+}
+
+func (this *Game) GetEvents() []*Event {
+	return append([]*Event{}, this.events...)
+}
+
+func (this *Game) recordEvent(eventType EventType, playerName string) *Event {
+	event := &Event{Type: eventType, Time: time.Now(), PlayerName: playerName}
+	this.events = append(this.events, event)
+	return event
+}
+
+func (this *Game) applyEvent(event *Event) error {
+	if event.Type == EventBita {
+		return this.MoveToBita()
+	}
+
+	if event.Type == EventPlayerLeft {
+		return this.HandlePlayerLeft(event.PlayerName)
+	}
+
+	player, err := this.GetPlayerByName(event.PlayerName)
+	if err != nil {
+		return err
+	}
+
+	switch event.Type {
+	case EventAttack:
+		return this.Attack(player, event.Cards...)
+	case EventDefend:
+		return this.Defend(player, event.Defences...)
+	case EventTransfer:
+		if len(event.Cards) != 1 {
+			return errors.New("transfer is done with a single card")
+		}
+		return this.Transfer(player, event.Cards[0])
+	case EventPass:
+		return this.Pass(player)
+	case EventTake:
+		return this.DeclareTake(player)
+	case EventKozerSwap:
+		return this.SwapKozer(player)
+	case EventRedeal:
+		return this.RequestRedeal(player)
+	case EventChallenge:
+		_, err := this.Challenge(player)
+		return err
+	}
+
+	return fmt.Errorf("unknown event type: %s", event.Type)
+}
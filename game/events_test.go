@@ -0,0 +1,113 @@
+package game
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func checkReplay(t *testing.T, game *Game) {
+	// Replayed events build the same position as the live game
+	t.Helper()
+	replayedGame, err := Replay(game.GetSeed(), game.GetEvents())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := json.Marshal(game.GetState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayedState, err := json.Marshal(replayedGame.GetState())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(state) != string(replayedState) {
+		t.Fatalf("replay of %d events differs:\n%s\n%s", len(game.GetEvents()), state, replayedState)
+	}
+	if game.GetPhase() != replayedGame.GetPhase() || game.GetLosingPlayerName() != replayedGame.GetLosingPlayerName() {
+		t.Fatalf("phase %s (%s lost) replayed as %s (%s lost)", game.GetPhase(), game.GetLosingPlayerName(),
+			replayedGame.GetPhase(), replayedGame.GetLosingPlayerName())
+	}
+}
+
+func TestReplay(t *testing.T) {
+	options := NewDefaultGameOptions()
+	options.Variant = Perevodnoy
+	options.Seed = 7
+	game, err := NewGame(options, "a", "b", "c", "d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	random := rand.New(rand.NewSource(7))
+	for numOfMoves := 0; !game.IsGameOver(); numOfMoves++ {
+		checkReplay(t, game)
+		if numOfMoves > 1000 {
+			t.Fatal("game is not over after 1000 moves")
+		}
+
+		if numOfMoves == 10 {
+			if err := game.HandlePlayerLeft("c"); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		playRandomMove(t, game, random)
+	}
+	checkReplay(t, game)
+}
+
+func TestReplayGameFromState(t *testing.T) {
+	options := NewDefaultGameOptions()
+	options.Seed = 5
+	game, err := NewGameFromState(&GameState{
+		Options: options,
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "7C", "8C", "9H")},
+			{Name: "b", Cards: cardsByCode(t, "6S", "10C", "JD")},
+			{Name: "c", Cards: cardsByCode(t, "QC", "KH", "6D")},
+		},
+		Deck:                cardsByCode(t, "9D", "10D", "AS"),
+		KozerCard:           cardsByCode(t, "AS")[0],
+		Board:               []*CardOnBoardState{{AttackingCard: cardsByCode(t, "7D")[0], AttackerName: "a"}},
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	random := rand.New(rand.NewSource(5))
+	for numOfMoves := 0; !game.IsGameOver(); numOfMoves++ {
+		checkReplay(t, game)
+		if numOfMoves > 1000 {
+			t.Fatal("game is not over after 1000 moves")
+		}
+
+		if numOfMoves == 3 {
+			if err := game.HandlePlayerLeft("a"); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		playRandomMove(t, game, random)
+	}
+	checkReplay(t, game)
+
+	// Events survive a snapshot, restored game can still be replayed
+	checkReplay(t, restoreSnapshot(t, game))
+}
+
+func TestReplayWithoutDeal(t *testing.T) {
+	game, err := NewGame(NewDefaultGameOptions(), "a", "b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Replay(game.GetSeed(), game.GetEvents()[1:]); err == nil {
+		t.Fatal("replay without deal must fail")
+	}
+	if _, err := Replay(game.GetSeed(), []*Event{{Type: EventDeal}}); err == nil {
+		t.Fatal("replay of deal without options must fail")
+	}
+}
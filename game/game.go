@@ -2,35 +2,80 @@ package game
 
 import (
 	"DurakGo/output"
-	"errors"
 	"fmt"
+	"math/rand"
+	"time"
 )
 
-// TODO Move to options
+// Range of all possible card values, game options decide which are in the deck
 const (
-	CardsPerPlayer    = 6
-	MaxCardsPerAttack = 6
-	MinCardValue      = 6
-	MaxCardValue      = 14
+	MinCardValue = 2
+	MaxCardValue = 14
 )
 
+// Losing with only these cards left in hand is pogony (epaulettes)
+const PogonyCardValue = 6
+
+type Defence struct {
+	AttackingCard *Card `json:"attackingCard"`
+	DefendingCard *Card `json:"defendingCard"`
+}
+
 type Game struct {
 	board              *Board
 	deck               *Deck
 	players            []*Player
 	startingPlayer     *Player
 	defendingPlayer    *Player
+	transferringPlayer *Player  // Last player that transferred the attack in current bout, starting player stays as is
 	KozerCard          *Card
 	numOfActivePlayers int
+	numOfPlayersAtStart int
+	options            *GameOptions
+	rules              RuleSet
+	phase              Phase
+	passedPlayers      map[*Player]bool
+	isTakeDeclared     bool
+	losingHand         []*Card  // All cards of losing players, both partners in team games
+	losingHands        map[string][]*Card
+	isAbandoned        bool  // Game ended because a player left, not by playing it out. Nobody loses such a game
+	redealPlayers      map[*Player]bool  // Players that may ask for a redeal, until first card is played
+	isKozerSwapOpen    bool              // Kozer card may be swapped until first card is played
+	events             []*Event
+	violations         []*Violation      // Illegal moves of current bout, shuler games only
+	seed               int64
+	rng                *rand.Rand
+	randomSource       *countingSource
+	deckOrder          []string  // Shuffled deck before dealing, published only as a commitment until game is over
+	deckSalt           string
+	deckCommitment     string
 }
 
 // Server API
 
-func NewGame(names ...string) (*Game, error) {
+func NewGame(options *GameOptions, names ...string) (*Game, error) {
+	if options == nil {
+		options = NewDefaultGameOptions()
+	}
+
+	if err := options.Validate(len(names)); err != nil {
+		return nil, err
+	}
+
+	rules, err := options.GetRuleSet()
+	if err != nil { return nil, err}
+
+	// All randomness of the game comes from its seed, so it can be reproduced
+	seed := options.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng, randomSource := newGameRandom(seed, 0)
+	output.Spit(fmt.Sprintf("Game seed: %d", seed))
+
 	// Create new deck
-	deck, err := NewDeck()
+	deck, err := newShuffledDeck(options, rng)
 	if err != nil { return nil, err}
-	deck.Shuffle()
 
 	// Create players
 	players := make([]*Player, 0)
@@ -45,110 +90,377 @@ func NewGame(names ...string) (*Game, error) {
 	}
 	lastPlayer.NextPlayer = players[0]
 
+	// Partners sit opposite each other
+	if options.IsTeamGame {
+		for i, player := range players {
+			player.Team = i%2 + 1
+		}
+	}
+
 	// Prepare game and cards
-	game := Game{board: NewBoard(), deck: deck, players: players, numOfActivePlayers: len(names)}
+	game := Game{board: NewBoard(), deck: deck, players: players, numOfActivePlayers: len(names),
+		numOfPlayersAtStart: len(names), options: options, rules: rules, seed: seed, rng: rng,
+		randomSource: randomSource}
+	if err := game.commitToDeck(); err != nil { return nil, err}
+	game.chooseKozer()  // Before dealing, as all cards are dealt when there are enough players
 	game.dealCards()
-	game.chooseKozer()
 	game.startGame()
 
+	dealOptions := *options
+	dealOptions.Seed = seed
+	dealEvent := game.recordEvent(EventDeal, "")
+	dealEvent.Options = &dealOptions
+	dealEvent.PlayerNames = append([]string{}, names...)
+
 	return &game, nil
 }
 
-func (this *Game) Attack(player *Player, card *Card) error {
-	if card == nil {
-		return fmt.Errorf("card is not valid (most likely nil)")
+func newShuffledDeck(options *GameOptions, rng *rand.Rand) (*Deck, error) {
+	minCardValue, err := options.GetMinCardValue()
+	if err != nil { return nil, err}
+	deck, err := NewDeck(minCardValue, options.GetMaxCardValue(), options.IsWithJokers, options.NumOfDecks)
+	if err != nil { return nil, err}
+	deck.Shuffle(rng)
+	deck.MoveJokersFromBottom()
+	return deck, nil
+}
+
+func (this *Game) Attack(player *Player, cards ...*Card) error {
+	// All cards are added together, or none of them
+
+	if err := this.validateAttack(player, cards); err != nil {
+		return err
 	}
 
-	if !this.canPlayerAttackNow(player) {
-		return fmt.Errorf("%s can not add attack now", player.Name)
+	// Must be checked before cards are on board
+	violation := this.checkAttackRules(cards)
+
+	for _, card := range cards {
+		// Remove card from player
+		card, err := player.GetCard(card)
+		if err != nil {return err}
+
+		output.Spit(fmt.Sprintf("%s attacked %s with %s", player.Name, this.defendingPlayer.Name, card))
+
+		this.board.AddAttackingCard(card, player)
 	}
 
-	if this.board.NumOfAttackingCards() >= MaxCardsPerAttack {
-		return errors.New("attacking cards limit reached")
+	if violation != nil {
+		this.recordViolation(player, cards, violation)
 	}
 
-	if len(this.board.peekUndefendedCards()) >= this.defendingPlayer.GetNumOfCardsInHand() {
-		return errors.New("player does not have enough cards to defend")
+	this.closeRedealWindow()
+	this.isKozerSwapOpen = false
+	this.resetPasses()
+	this.updatePhase()
+	this.pickUpCardsIfRequired()
+	this.recordEvent(EventAttack, player.Name).Cards = append([]*Card{}, cards...)
+	return nil
+
+}
+
+func (this *Game) Defend(player *Player, defences ...*Defence) error {
+	// All defences are applied together, or none of them
+
+	if err := this.validateDefence(player, defences); err != nil {
+		return err
 	}
 
-	if !this.board.IsEmpty() && !this.board.CanCardBeAdded(card) {
-		return fmt.Errorf("%s is not a valid card to attack with at this moment", card)
+	violation := this.checkDefenceRules(defences)
+
+	for _, defence := range defences {
+		// Remove card from player
+		defendingCard, err := player.GetCard(defence.DefendingCard)
+		if err != nil {
+			return err
+		}
+
+		// Add card to board
+		if err = this.board.AddDefendingCard(defence.AttackingCard, defendingCard, player); err != nil {
+			player.TakeCards(defendingCard)  // Return card to player
+			return err
+		}
+
+		output.Spit(fmt.Sprintf("%s defended %s with %s", player.Name, defence.AttackingCard, defendingCard))
+	}
+
+	if violation != nil {
+		defendingCards := make([]*Card, 0, len(defences))
+		for _, defence := range defences {
+			defendingCards = append(defendingCards, defence.DefendingCard)
+		}
+		this.recordViolation(player, defendingCards, violation)
+	}
+
+	this.resetPasses()
+	this.updatePhase()
+	this.recordEvent(EventDefend, player.Name).Defences = append([]*Defence{}, defences...)
+	return nil
+}
+
+func (this *Game) Transfer(player *Player, card *Card) error {
+	if err := this.validateTransfer(player, card); err != nil {
+		return err
 	}
 
 	// Remove card from player
 	card, err := player.GetCard(card)
 	if err != nil {return err}
 
-	output.Spit(fmt.Sprintf("%s attacked %s with %s", player.Name, this.defendingPlayer.Name, card))
+	nextDefendingPlayer := this.getNextOpponent(player)
+	output.Spit(fmt.Sprintf("%s transferred attack to %s with %s", player.Name, nextDefendingPlayer.Name, card))
 
 	this.board.AddAttackingCard(card, player)
+	this.transferringPlayer = player
+	this.defendingPlayer = nextDefendingPlayer
+	this.resetPasses()
+	this.updatePhase()
+	this.recordEvent(EventTransfer, player.Name).Cards = []*Card{card}
 	return nil
-
 }
 
-func (this *Game) Defend(player *Player, attackingCard *Card, defendingCard *Card) error {
-	if attackingCard == nil || defendingCard == nil {
-		return errors.New("attacking or defending card is invalid (probably nil)")
+func (this *Game) validateTransfer(player *Player, card *Card) error {
+	if err := this.validateGameNotOver(); err != nil {
+		return err
+	}
+
+	if card == nil {
+		return newGameError(ErrInvalidCard, "card is not valid (most likely nil)")
 	}
 
 	if this.defendingPlayer != player {
-		return fmt.Errorf("%s is not defending now", player.Name)
+		return newGameError(ErrNotYourTurn, "%s is not defending now", player.Name)
 	}
 
-	// Check defending card can defend this card
-	if !defendingCard.CanDefendCard(attackingCard, &this.KozerCard.Kind) {
-		return fmt.Errorf("%v can not defend %v\n", defendingCard, attackingCard)
+	if this.phase != PhaseDefending {
+		return newGameError(ErrWrongPhase, "there is no attack to transfer")
 	}
 
-	// Remove card from player
-	defendingCard, err := player.GetCard(defendingCard)
+	if !this.rules.CanCardBeTransferred(this.board, card) {
+		return newGameError(ErrCannotTransfer, "%s can not be used to transfer at this moment", card)
+	}
 
-	if err != nil {
-		return err
+	if this.board.NumOfAttackingCards() >= this.options.MaxCardsPerAttack {
+		return newGameError(ErrAttackLimit, "attacking cards limit reached")
 	}
 
-	// Add card to board
-	err = this.board.AddDefendingCard(attackingCard, defendingCard, player)
+	// Next defending player must be able to answer all cards, including the transferred one
+	nextDefendingPlayer := this.getNextOpponent(player)
+	if this.board.NumOfAttackingCards()+1 > nextDefendingPlayer.GetNumOfCardsInHand() {
+		return newGameError(ErrAttackLimit, "%s does not have enough cards to defend", nextDefendingPlayer.Name)
+	}
 
-	output.Spit(fmt.Sprintf("%s defended %s with %s", player.Name, attackingCard, defendingCard))
+	if !player.HasCard(card) {
+		return newGameError(ErrCardNotInHand, "%s is not in %s's hand", card, player.Name)
+	}
 
-	if err != nil {
-		player.TakeCards(defendingCard)  // Return card to player
+	return nil
+}
+
+func (this *Game) Pass(player *Player) error {
+	// Attacker is done adding cards for this bout
+
+	if err := this.validatePass(player); err != nil {
 		return err
 	}
+
+	output.Spit(fmt.Sprintf("%s passed", player.Name))
+
+	this.passedPlayers[player] = true
+	this.updatePhase()
+	this.pickUpCardsIfRequired()
+	this.recordEvent(EventPass, player.Name)
 	return nil
 }
 
-func (this *Game) MoveToBita() error {
+func (this *Game) validatePass(player *Player) error {
+	if err := this.validateGameNotOver(); err != nil {
+		return err
+	}
+
 	if this.board.IsEmpty() {
-		return errors.New("board is empty")
+		return newGameError(ErrWrongPhase, "board is empty, attack first")
 	}
 
-	if !this.board.AreAllCardsDefended() {
-		return errors.New("some cards are un defended")
+	if this.phase != PhaseAttacking && this.phase != PhaseDefenderTaking {
+		return newGameError(ErrWrongPhase, "can not pass while game is in %s phase", this.phase)
+	}
+
+	if player == this.defendingPlayer || !this.canPlayerAttackNow(player) {
+		return newGameError(ErrNotYourTurn, "%s is not attacking now", player.Name)
+	}
+
+	if this.passedPlayers[player] {
+		return newGameError(ErrAlreadyDone, "%s has already passed", player.Name)
+	}
+
+	return nil
+}
+
+func (this *Game) RequestRedeal(player *Player) error {
+	if err := this.validateGameNotOver(); err != nil {
+		return err
+	}
+
+	if !this.redealPlayers[player] {
+		return newGameError(ErrNotAllowed, "%s can not ask for a redeal", player.Name)
+	}
+
+	output.Spit(fmt.Sprintf("%s asked for a redeal", player.Name))
+
+	deck, err := newShuffledDeck(this.options, this.rng)
+	if err != nil {return err}
+
+	for _, p := range this.players {
+		p.clearHand()
+	}
+	this.deck = deck
+	if err := this.commitToDeck(); err != nil {return err}
+	this.chooseKozer()
+	this.dealCards()
+	this.startGame()
+	this.recordEvent(EventRedeal, player.Name)
+	return nil
+}
+
+func (this *Game) GetRedealPlayerNames() []string {
+	names := make([]string, 0)
+	for _, player := range this.players {
+		if this.redealPlayers[player] {
+			names = append(names, player.Name)
+		}
 	}
+	return names
+}
+
+func (this *Game) SwapKozer(player *Player) error {
+	// Player holding the lowest kozer can replace the kozer card with it, before first attack
+
+	if !this.options.IsKozerSwapAllowed {
+		return newGameError(ErrNotAllowed, "swapping kozer is not allowed in this game")
+	}
+
+	if err := this.validateGameNotOver(); err != nil {
+		return err
+	}
+
+	if this.deck.GetNumOfCardsLeft() == 0 {
+		return newGameError(ErrWrongPhase, "deck is empty, kozer card was already taken")
+	}
+
+	if !this.isKozerSwapOpen {
+		return newGameError(ErrWrongPhase, "kozer card can be swapped only before first attack")
+	}
+
+	lowestKozer, err := this.getLowestKozer()
+	if err != nil {return err}
+
+	// Remove card from player
+	newKozerCard, err := player.GetCardOfAnyDeck(lowestKozer)
+	if err != nil {
+		return newGameError(ErrCardNotInHand, "%s does not have %s", player.Name, lowestKozer)
+	}
+
+	oldKozerCard := this.deck.SwapLastCard(newKozerCard)
+	player.TakeCards(oldKozerCard)
+	this.KozerCard = newKozerCard
+
+	output.Spit(fmt.Sprintf("%s swapped %s with kozer card %s", player.Name, newKozerCard, oldKozerCard))
+	this.recordEvent(EventKozerSwap, player.Name)
+	return nil
+}
+
+func (this *Game) GetSeed() int64 {
+	return this.seed
+}
+
+func (this *Game) GetOptions() *GameOptions {
+	return this.options
+}
+
+func (this *Game) MoveToBita() error {
+	if err := this.validateBita(); err != nil {
+		return err
+	}
+
 	this.board.EmptyBoard()
 	this.fillUpCards()
 	output.Spit(fmt.Sprintf("Cards going to bitas"))
 	this.finalizeTurn(true)
+	this.recordEvent(EventBita, "")
 	return nil
 }
 
-func (this *Game) PickUpCards() error {
+func (this *Game) validateBita() error {
+	if err := this.validateGameNotOver(); err != nil {
+		return err
+	}
+
 	if this.board.IsEmpty() {
-		return errors.New("board is empty")
+		return newGameError(ErrWrongPhase, "board is empty")
+	}
+
+	if !this.board.AreAllCardsDefended() {
+		return newGameError(ErrWrongPhase, "some cards are un defended")
+	}
+
+	if this.phase != PhaseBoutComplete {
+		return newGameError(ErrWrongPhase, "attackers did not finish adding cards")
+	}
+
+	return nil
+}
+
+func (this *Game) DeclareTake(player *Player) error {
+	// Defending player gives up, attackers may still add cards before they are picked up
+
+	if err := this.validateTake(player); err != nil {
+		return err
+	}
+
+	output.Spit(fmt.Sprintf("%s is taking cards", player.Name))
+
+	this.isTakeDeclared = true
+	this.resetPasses()
+	this.updatePhase()
+	this.pickUpCardsIfRequired()
+	this.recordEvent(EventTake, player.Name)
+	return nil
+}
+
+func (this *Game) validateTake(player *Player) error {
+	if err := this.validateGameNotOver(); err != nil {
+		return err
+	}
+
+	if this.board.IsEmpty() {
+		return newGameError(ErrWrongPhase, "board is empty")
+	}
+
+	if this.defendingPlayer != player {
+		return newGameError(ErrNotYourTurn, "%s is not defending now", player.Name)
+	}
+
+	if this.isTakeDeclared {
+		return newGameError(ErrAlreadyDone, "%s has already declared taking", player.Name)
+	}
+
+	if this.phase == PhaseBoutComplete {
+		return newGameError(ErrWrongPhase, "all cards are defended, bout is complete")
 	}
 
-	cards := this.board.PeekCards()
-	output.Spit(fmt.Sprintf("%s picking up cards", this.defendingPlayer))
-	this.defendingPlayer.TakeCards(cards...)
-	this.board.EmptyBoard()
-	this.fillUpCards()
-	this.finalizeTurn(false)
 	return nil
 }
 
+func (this *Game) IsTakeDeclared() bool {
+	return this.isTakeDeclared
+}
+
 func (this *Game) IsGameOver() bool {
+	if this.options.IsTeamGame {
+		// Partners never attack each other, so game is over once a single team is left
+		return len(this.getActiveTeams()) < 2
+	}
 	return this.numOfActivePlayers < 2
 }
 
@@ -156,19 +468,26 @@ func (this *Game) IsDraw() bool {
 	return this.numOfActivePlayers == 0
 }
 
+func (this *Game) IsAbandoned() bool {
+	return this.isAbandoned
+}
+
 func (this *Game) GetPlayerByName(name string) (*Player, error) {
 	for _, player := range this.players {
 		if player.Name == name {
 			return player, nil
 		}
 	}
-	return nil, fmt.Errorf("no such player exists: %s", name)
+	return nil, newGameError(ErrPlayerNotFound, "no such player exists: %s", name)
 }
 
 func (this *Game) GetLosingPlayer() *Player {
 	if !this.IsGameOver() {
 		return nil
-	} else if this.IsDraw() {
+	} else if this.IsDraw() || this.isAbandoned {
+		return nil
+	} else if this.options.IsTeamGame {
+		// Both partners may still hold cards, use GetLosingTeam
 		return nil
 	}
 	for _, p := range this.players {
@@ -179,6 +498,87 @@ func (this *Game) GetLosingPlayer() *Player {
 	return nil
 }
 
+func (this *Game) GetLosingPlayers() []*Player {
+	// Players left with cards, in team games both partners may still hold cards
+
+	losingPlayers := make([]*Player, 0)
+	if losingPlayer := this.GetLosingPlayer(); losingPlayer != nil {
+		return append(losingPlayers, losingPlayer)
+	}
+
+	losingTeam := this.GetLosingTeam()
+	if losingTeam == 0 {
+		return losingPlayers
+	}
+	for _, player := range this.players {
+		if player.Team == losingTeam && player.GetNumOfCardsInHand() > 0 {
+			losingPlayers = append(losingPlayers, player)
+		}
+	}
+	return losingPlayers
+}
+
+func (this *Game) GetLosingPlayerFinalHand() []*Card {
+	// Cards the losing player held when game ended, cards of the whole losing team in team games
+	return append([]*Card{}, this.losingHand...)
+}
+
+func (this *Game) GetLosingFinalHands() map[string][]*Card {
+	// Cards each losing player held when game ended
+	hands := make(map[string][]*Card)
+	for name, cards := range this.losingHands {
+		hands[name] = append([]*Card{}, cards...)
+	}
+	return hands
+}
+
+func (this *Game) IsPogony() bool {
+	if len(this.losingHand) == 0 {
+		return false
+	}
+	for _, card := range this.losingHand {
+		if card.Value != PogonyCardValue {
+			return false
+		}
+	}
+	return true
+}
+
+func (this *Game) GetLosingTeam() int {
+	// Team of the players left with cards, 0 if there is none
+
+	if !this.options.IsTeamGame || !this.IsGameOver() || this.IsDraw() || this.isAbandoned {
+		return 0
+	}
+	activeTeams := this.getActiveTeams()
+	if len(activeTeams) != 1 {
+		return 0
+	}
+	return activeTeams[0]
+}
+
+func (this *Game) GetLosingTeamPlayerNames() []string {
+	names := make([]string, 0)
+	losingTeam := this.GetLosingTeam()
+	if losingTeam == 0 {
+		return names
+	}
+	for _, player := range this.players {
+		if player.Team == losingTeam {
+			names = append(names, player.Name)
+		}
+	}
+	return names
+}
+
+func (this *Game) GetPlayerTeamsMap() map[string]int {
+	playerTeams := make(map[string]int)
+	for _, player := range this.players {
+		playerTeams[player.Name] = player.Team
+	}
+	return playerTeams
+}
+
 func (this *Game) GetPlayersCardsMap() map[string][]*Card {
 	playerCards := make(map[string][]*Card)
 	for _, player := range this.players {
@@ -197,6 +597,18 @@ func (this *Game) GetDefendingPlayer() *Player {
 	return this.defendingPlayer
 }
 
+func (this *Game) GetTransferringPlayer() *Player {
+	// nil if attack was not transferred in current bout
+	return this.transferringPlayer
+}
+
+func (this *Game) GetTransferringPlayerName() string {
+	if this.transferringPlayer == nil {
+		return ""
+	}
+	return this.transferringPlayer.Name
+}
+
 func (this *Game) GetLosingPlayerName() string {
 	losingPlayer := this.GetLosingPlayer()
 	if losingPlayer == nil {
@@ -228,27 +640,64 @@ func (this *Game) HandlePlayerLeft(name string) error {
 	if err != nil {
 		return err
 	}
+	this.recordEvent(EventPlayerLeft, name)
+
+	// Players that already finished are not in the ring anymore
+	wasPlaying := leavingPlayer.IsPlaying
+	wasGameOver := this.IsGameOver()
 
 	this.removePlayerFromGame(leavingPlayer)
-	if this.IsGameOver() {
+	if !wasPlaying || wasGameOver {
 		return nil
 	}
 
-	if this.defendingPlayer == leavingPlayer {
+	if this.IsGameOver() {
+		// Bout is never completed, cards on board go back to their owners.
+		// Starting and defending players are kept as they were, nobody is on turn anymore
+		this.isAbandoned = true
+		this.board.ReturnCardsOnBoardToOwners()
+		this.board.EmptyBoard()
+		this.isTakeDeclared = false
+		this.transferringPlayer = nil
+		this.violations = nil
+	} else if this.defendingPlayer == leavingPlayer {
 		this.board.ReturnCardsOnBoardToOwners()
 		this.board.EmptyBoard()
+		this.isTakeDeclared = false
+		this.transferringPlayer = nil
+		if this.startingPlayer == leavingPlayer {
+			// Attack was transferred all the way back to the player that opened it
+			this.startingPlayer = this.getPreviousPlayer(leavingPlayer)
+		}
 		this.defendingPlayer = leavingPlayer.NextPlayer
+		if this.defendingPlayer == this.startingPlayer || this.arePartners(this.startingPlayer, this.defendingPlayer) {
+			this.defendingPlayer = this.getNextOpponent(this.defendingPlayer)
+		}
 	} else {
+		// Starting player must stay in the ring, fill up order starts from it
 		if this.startingPlayer == leavingPlayer {
-			if this.board.IsEmpty() {
-				this.startingPlayer = leavingPlayer.NextPlayer
+			this.startingPlayer = leavingPlayer.NextPlayer
+			if this.startingPlayer == this.defendingPlayer {
+				this.startingPlayer = this.defendingPlayer.NextPlayer
 			}
 		}
+		if this.transferringPlayer == leavingPlayer {
+			this.transferringPlayer = nil
+		}
 	}
 
 	prevPlayer := this.getPreviousPlayer(leavingPlayer)
 	prevPlayer.NextPlayer = leavingPlayer.NextPlayer
 
+	delete(this.passedPlayers, leavingPlayer)
+	this.updatePhase()
+
+	if this.IsGameOver() {
+		this.recordLosingHand()
+		return nil
+	}
+	this.pickUpCardsIfRequired()
+
 	return nil
 }
 
@@ -262,13 +711,47 @@ func (this *Game) finalizeTurn(wasDefendedSuccessfully bool) {
 		this.removePlayersThatFinished()
 	}
 
-	if !this.IsGameOver() {
+	if this.IsGameOver() {
+		this.recordLosingHand()
+	} else {
 		this.setUpNextTurn(wasDefendedSuccessfully)
 	}
+
+	this.isTakeDeclared = false
+	this.transferringPlayer = nil
+	this.violations = nil  // Challenge window closes with the bout
+	this.resetPasses()
+	this.updatePhase()
+}
+
+func (this *Game) pickUpCardsIfRequired() {
+	// Defending player picks up once attackers are done adding cards
+
+	if !this.isTakeDeclared || (this.canMoreCardsBeAdded() && !this.haveAllAttackersPassed()) {
+		return
+	}
+
+	cards := this.board.PeekCards()
+	output.Spit(fmt.Sprintf("%s picking up cards", this.defendingPlayer))
+	this.defendingPlayer.TakeCards(cards...)
+	this.board.EmptyBoard()
+	this.fillUpCards()
+	this.finalizeTurn(false)
+}
+
+func (this *Game) recordLosingHand() {
+	// Keeps a copy of losing players' cards, hands themselves may change later on
+
+	this.losingHand = make([]*Card, 0)
+	this.losingHands = make(map[string][]*Card)
+	for _, losingPlayer := range this.GetLosingPlayers() {
+		this.losingHand = append(this.losingHand, losingPlayer.PeekCards()...)
+		this.losingHands[losingPlayer.Name] = losingPlayer.PeekCards()
+	}
 }
 
 func (this *Game) dealCards() {
-	for i := 1; i <= CardsPerPlayer; i++ {
+	for i := 1; i <= this.options.CardsPerPlayer; i++ {
 		for _, player := range this.players {
 			player.TakeCards(this.deck.GetNextCard())
 		}
@@ -276,6 +759,7 @@ func (this *Game) dealCards() {
 }
 
 func (this *Game) chooseKozer() {
+	// Last card in deck, which is also the last card dealt when no cards are left in deck
 	lastCardInDeck := this.deck.PeekLastCard()
 	this.KozerCard = lastCardInDeck
 	output.Spit(fmt.Sprintf("Kozer selected: %s", this.KozerCard))
@@ -284,9 +768,74 @@ func (this *Game) chooseKozer() {
 func (this *Game) startGame() {
 	this.startingPlayer = this.getStartingPlayer()
 	this.defendingPlayer = this.startingPlayer.NextPlayer
+	this.transferringPlayer = nil
+	this.resetPasses()
+	this.updatePhase()
+	this.openRedealWindow()
+	this.isKozerSwapOpen = true
+}
+
+func (this *Game) openRedealWindow() {
+	this.redealPlayers = make(map[*Player]bool)
+	for _, player := range this.players {
+		if this.isRedealAllowed(player.PeekCards()) {
+			this.redealPlayers[player] = true
+		}
+	}
+}
+
+func (this *Game) closeRedealWindow() {
+	this.redealPlayers = make(map[*Player]bool)
+}
+
+func (this *Game) isRedealAllowed(hand []*Card) bool {
+	// Checks configured redeal rules against a starting hand
+
+	cardsPerKind := make(map[Kind]int)
+	hasKozer := false
+	for _, card := range hand {
+		cardsPerKind[card.Kind]++
+		if card.Kind == this.KozerCard.Kind || card.IsJoker() {
+			hasKozer = true
+		}
+	}
+
+	if this.options.IsRedealOnNoKozer && !hasKozer {
+		return true
+	}
+
+	if this.options.RedealSameKindCount > 0 {
+		for kind, count := range cardsPerKind {
+			if !IsJokerKind(kind) && count >= this.options.RedealSameKindCount {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 func (this *Game) getStartingPlayer() *Player {
+	switch this.options.StartingPlayer {
+	case FirstPlayerStarts:
+		return this.players[0]
+	case RandomPlayerStarts:
+		return this.players[this.rng.Intn(len(this.players))]
+	case PreviousDurakStarts:
+		if previousDurak, err := this.GetPlayerByName(this.options.PreviousDurakName); err == nil {
+			return previousDurak
+		}
+		return this.getPlayerWithLowestKozer()
+	case LeftOfDurakStarts:
+		if previousDurak, err := this.GetPlayerByName(this.options.PreviousDurakName); err == nil {
+			return previousDurak.NextPlayer
+		}
+		return this.getPlayerWithLowestKozer()
+	default:
+		return this.getPlayerWithLowestKozer()
+	}
+}
+
+func (this *Game) getPlayerWithLowestKozer() *Player {
 	// Check player with lowest kozer, or use default
 
 	kozerKind := this.KozerCard.Kind
@@ -302,61 +851,162 @@ func (this *Game) getStartingPlayer() *Player {
 		}
 	}
 
-	// TODO Add attack durak from last round
-
 	return playerStarting
 }
 
-func (this *Game) canPlayerAttackNow(player *Player) bool {
-	// Checks if a player has the right to attack with a card
+func (this *Game) getLowestKozer() (*Card, error) {
+	minCardValue, err := this.options.GetMinCardValue()
+	if err != nil {return nil, err}
+	return NewCard(this.KozerCard.Kind, minCardValue)
+}
 
-	if this.board.IsEmpty() {
-		return this.startingPlayer == player
-	} else {
-		return player != this.defendingPlayer
+func (this *Game) validateAttack(player *Player, cards []*Card) error {
+	if len(cards) == 0 {
+		return newGameError(ErrInvalidCard, "no cards to attack with")
 	}
+
+	for i, card := range cards {
+		if card == nil {
+			return newGameError(ErrInvalidCard, "card is not valid (most likely nil)")
+		}
+		for _, otherCard := range cards[:i] {
+			if card.IsSameCard(otherCard) {
+				return newGameError(ErrInvalidCard, "%s is used more than once", card)
+			}
+		}
+	}
+
+	if err := this.validateGameNotOver(); err != nil {
+		return err
+	}
+
+	if this.phase == PhaseBoutComplete {
+		return newGameError(ErrWrongPhase, "bout is complete, no more cards can be added")
+	}
+
+	if !this.canPlayerAttackNow(player) {
+		return newGameError(ErrNotYourTurn, "%s can not add attack now", player.Name)
+	}
+
+	if this.board.NumOfAttackingCards()+len(cards) > this.options.MaxCardsPerAttack {
+		return newGameError(ErrAttackLimit, "attacking cards limit reached")
+	}
+
+	if len(this.board.peekUndefendedCards())+len(cards) > this.defendingPlayer.GetNumOfCardsInHand() {
+		return newGameError(ErrAttackLimit, "player does not have enough cards to defend")
+	}
+
+	for _, card := range cards {
+		if !player.HasCard(card) {
+			return newGameError(ErrCardNotInHand, "%s is not in %s's hand", card, player.Name)
+		}
+	}
+
+	// In shuler games breaking the rules is allowed, it is only recorded
+	if !this.options.IsShuler {
+		return this.checkAttackRules(cards)
+	}
+
+	return nil
 }
 
-func (this *Game) fillUpCards() {
+func (this *Game) checkAttackRules(cards []*Card) error {
+	for _, card := range cards {
+		if this.board.IsEmpty() && card.Value != cards[0].Value {
+			return newGameError(ErrCannotAdd, "cards opening an attack must have the same value")
+		}
 
-	// Check if there is a deck
-	numOfCardsInDeck := this.deck.GetNumOfCardsLeft()
-	if numOfCardsInDeck == 0 {
-		return
+		if !this.board.IsEmpty() && !this.rules.CanCardBeAdded(this.board, card) {
+			return newGameError(ErrCannotAdd, "%s is not a valid card to attack with at this moment", card)
+		}
+	}
+
+	return nil
+}
+
+func (this *Game) validateDefence(player *Player, defences []*Defence) error {
+	if len(defences) == 0 {
+		return newGameError(ErrInvalidCard, "no cards to defend with")
 	}
 
-	playerFillingUpLast := this.getPlayerFillingUpLast()
-	playerFillingUp := this.getPlayerFillingUpFirst()
-	playerFilledUpCounter := 0
+	if err := this.validateGameNotOver(); err != nil {
+		return err
+	}
+
+	if this.defendingPlayer != player {
+		return newGameError(ErrNotYourTurn, "%s is not defending now", player.Name)
+	}
 
-	for playerFilledUpCounter < this.numOfActivePlayers {
-		if playerFilledUpCounter != this.numOfActivePlayers- 1 {
-			if playerFillingUp == playerFillingUpLast {
-				playerFillingUp = playerFillingUp.NextPlayer
-			} else {
-				this.fillUpCardsForPlayer(playerFillingUp)
-				playerFillingUp = playerFillingUp.NextPlayer
-				playerFilledUpCounter = playerFilledUpCounter + 1
+	if this.phase != PhaseDefending {
+		return newGameError(ErrWrongPhase, "there are no cards to defend")
+	}
+
+	for i, defence := range defences {
+		if defence == nil || defence.AttackingCard == nil || defence.DefendingCard == nil {
+			return newGameError(ErrInvalidCard, "attacking or defending card is invalid (probably nil)")
+		}
+
+		for _, otherDefence := range defences[:i] {
+			if defence.AttackingCard.IsSameCard(otherDefence.AttackingCard) {
+				return newGameError(ErrInvalidCard, "%s is defended more than once", defence.AttackingCard)
 			}
-		} else {
-			this.fillUpCardsForPlayer(playerFillingUpLast)
-			break
+			if defence.DefendingCard.IsSameCard(otherDefence.DefendingCard) {
+				return newGameError(ErrInvalidCard, "%s is used more than once", defence.DefendingCard)
+			}
+		}
+
+		if !this.board.IsCardUndefended(defence.AttackingCard) {
+			return newGameError(ErrInvalidCard, "%s is not an undefended card on board", defence.AttackingCard)
+		}
+
+		if !player.HasCard(defence.DefendingCard) {
+			return newGameError(ErrCardNotInHand, "%s is not in %s's hand", defence.DefendingCard, player.Name)
+		}
+	}
+
+	// In shuler games breaking the rules is allowed, it is only recorded
+	if !this.options.IsShuler {
+		return this.checkDefenceRules(defences)
+	}
+
+	return nil
+}
+
+func (this *Game) checkDefenceRules(defences []*Defence) error {
+	for _, defence := range defences {
+		// Check defending card can defend this card
+		if !this.rules.CanCardDefend(defence.DefendingCard, defence.AttackingCard, this.KozerCard.Kind) {
+			return newGameError(ErrCannotBeat, "%v can not defend %v", defence.DefendingCard, defence.AttackingCard)
 		}
 	}
 
+	return nil
 }
 
-func (this *Game) getPlayerFillingUpLast() *Player {
-	return this.defendingPlayer
+func (this *Game) canPlayerAttackNow(player *Player) bool {
+	// Checks if a player has the right to attack with a card
+	if this.arePartners(player, this.defendingPlayer) {
+		return false
+	}
+	return this.rules.CanPlayerAttackNow(this, player)
 }
 
-func (this *Game) getPlayerFillingUpFirst() *Player {
-	return this.startingPlayer
+func (this *Game) fillUpCards() {
+
+	// Check if there is a deck
+	numOfCardsInDeck := this.deck.GetNumOfCardsLeft()
+	if numOfCardsInDeck == 0 {
+		return
+	}
+
+	for _, player := range this.rules.GetFillUpOrder(this) {
+		this.fillUpCardsForPlayer(player)
+	}
 }
 
 func (this *Game) fillUpCardsForPlayer(player *Player) {
 
-	for CardsPerPlayer- player.GetNumOfCardsInHand() > 0 {
+	for this.options.CardsPerPlayer- player.GetNumOfCardsInHand() > 0 {
 		if this.deck.GetNumOfCardsLeft() == 0 {
 			return
 		}
@@ -367,14 +1017,11 @@ func (this *Game) fillUpCardsForPlayer(player *Player) {
 
 func (this *Game) setUpNextTurn(wasLastTurnDefended bool) {
 
-	if wasLastTurnDefended && this.defendingPlayer.GetNumOfCardsInHand() > 0 {
-			this.startingPlayer = this.defendingPlayer
-	} else {
-		this.startingPlayer = this.defendingPlayer.NextPlayer
+	this.startingPlayer, this.defendingPlayer = this.rules.GetNextTurnPlayers(this, wasLastTurnDefended)
+	if this.arePartners(this.startingPlayer, this.defendingPlayer) {
+		this.defendingPlayer = this.getNextOpponent(this.startingPlayer)
 	}
 
-	this.defendingPlayer = this.startingPlayer.NextPlayer
-
 	output.Spit(fmt.Sprintf("Setting up next turn: %s defending, %s starting", this.defendingPlayer.Name, this.startingPlayer.Name))
 }
 
@@ -385,12 +1032,23 @@ func (this *Game) removePlayersThatFinished() {
 	for i := 0; i < this.numOfActivePlayers; i++ {
 		if currentPlayer.GetNumOfCardsInHand() == 0 {
 			playersRemoved++
+			currentPlayer.IsPlaying = false
 			previousPlayer := this.getPreviousPlayer(currentPlayer)
 			previousPlayer.NextPlayer = currentPlayer.NextPlayer
 		}
 		currentPlayer = currentPlayer.NextPlayer
 	}
 	this.numOfActivePlayers = this.numOfActivePlayers - playersRemoved
+
+	// Removed players may still be defending or starting, keep them pointing into the ring
+	if this.numOfActivePlayers == 0 {
+		return
+	}
+	for _, player := range this.players {
+		for !player.IsPlaying && !player.NextPlayer.IsPlaying {
+			player.NextPlayer = player.NextPlayer.NextPlayer
+		}
+	}
 }
 
 func (this *Game) getPreviousPlayer(player *Player) *Player {
@@ -409,7 +1067,43 @@ func (this *Game) removePlayerFromGame(player *Player) {
 		}
 	}
 
-	this.numOfActivePlayers = this.numOfActivePlayers - 1
+	// Player may have already finished
+	if player.IsPlaying {
+		this.numOfActivePlayers = this.numOfActivePlayers - 1
+	}
+	player.IsPlaying = false
 
 	output.Spit(fmt.Sprintf("Player %s is removed from game", player.Name))
+}
+
+func (this *Game) arePartners(player *Player, otherPlayer *Player) bool {
+	return this.options.IsTeamGame && player != otherPlayer && player.Team == otherPlayer.Team
+}
+
+func (this *Game) getNextOpponent(player *Player) *Player {
+	// Next player in ring that is not a partner of player
+	p := player.NextPlayer
+	for this.arePartners(player, p) && p.NextPlayer != player {
+		p = p.NextPlayer
+	}
+	return p
+}
+
+func (this *Game) getActiveTeams() []int {
+	teams := make([]int, 0)
+	for _, player := range this.players {
+		if !player.IsPlaying {
+			continue
+		}
+		isTeamFound := false
+		for _, team := range teams {
+			if team == player.Team {
+				isTeamFound = true
+			}
+		}
+		if !isTeamFound {
+			teams = append(teams, player.Team)
+		}
+	}
+	return teams
 }
\ No newline at end of file
@@ -0,0 +1,715 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func cardsByCode(t *testing.T, codes ...string) []*Card {
+	t.Helper()
+	cards := make([]*Card, 0)
+	for _, code := range codes {
+		card, err := NewCardByCode(code)
+		if err != nil {
+			t.Fatalf("could not parse card code %s: %s", code, err)
+		}
+		cards = append(cards, card)
+	}
+	return cards
+}
+
+func getPlayerNames(players []*Player) []string {
+	names := make([]string, 0)
+	for _, player := range players {
+		names = append(names, player.Name)
+	}
+	return names
+}
+
+func playMove(game *Game, player *Player, move *Move) error {
+	switch move.Type {
+	case MoveAttack:
+		return game.Attack(player, move.Card)
+	case MoveDefend:
+		return game.Defend(player, move.Defence)
+	case MoveTransfer:
+		return game.Transfer(player, move.Card)
+	case MovePass:
+		return game.Pass(player)
+	case MoveTake:
+		return game.DeclareTake(player)
+	case MoveBita:
+		return game.MoveToBita()
+	}
+	return fmt.Errorf("unknown move %s", move.Type)
+}
+
+func playRandomMove(t *testing.T, game *Game, random *rand.Rand) {
+	// Plays one of the legal moves of all players
+	t.Helper()
+	players := make([]*Player, 0)
+	moves := make([]*Move, 0)
+	for _, player := range game.players {
+		for _, move := range game.LegalMoves(player) {
+			players = append(players, player)
+			moves = append(moves, move)
+		}
+	}
+	if len(moves) == 0 {
+		t.Fatalf("no legal moves while game is not over, phase is %s", game.GetPhase())
+	}
+
+	i := random.Intn(len(moves))
+	if err := playMove(game, players[i], moves[i]); err != nil {
+		t.Fatalf("legal move %s of %s was refused: %s", moves[i].Type, players[i].Name, err)
+	}
+}
+
+func TestTransferKeepsFillUpOrderOfOpeningAttacker(t *testing.T) {
+	options := NewDefaultGameOptions()
+	options.Variant = Perevodnoy
+	game, err := NewGameFromState(&GameState{
+		Options: options,
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "8H", "9H")},
+			{Name: "b", Cards: cardsByCode(t, "7S", "10H")},
+			{Name: "c", Cards: cardsByCode(t, "JH", "QH", "KH")},
+		},
+		Deck:                cardsByCode(t, "6D", "7D", "8D", "AC"),
+		KozerCard:           cardsByCode(t, "AC")[0],
+		Board:               []*CardOnBoardState{{AttackingCard: cardsByCode(t, "7H")[0], AttackerName: "a"}},
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := game.GetPlayerByName("b")
+	if err := game.Transfer(b, cardsByCode(t, "7S")[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if game.GetStartingPlayer().Name != "a" || game.GetDefendingPlayer().Name != "c" || game.GetTransferringPlayerName() != "b" {
+		t.Fatalf("unexpected players after transfer: starting %s, defending %s, transferring %s",
+			game.GetStartingPlayer().Name, game.GetDefendingPlayer().Name, game.GetTransferringPlayerName())
+	}
+
+	order := getPlayerNames(game.rules.GetFillUpOrder(game))
+	if len(order) != 3 || order[0] != "a" || order[1] != "b" || order[2] != "c" {
+		t.Fatalf("fill up order must start from opening attacker, got %v", order)
+	}
+
+	// Transfer is over with the bout
+	c, _ := game.GetPlayerByName("c")
+	if err := game.DeclareTake(c); err != nil {
+		t.Fatal(err)
+	}
+	a, _ := game.GetPlayerByName("a")
+	for _, player := range []*Player{a, b} {
+		if err := game.Pass(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if game.GetTransferringPlayer() != nil {
+		t.Fatalf("transferring player must be cleared once bout is over")
+	}
+	if a.GetNumOfCardsInHand() != 6 {
+		t.Fatalf("opening attacker must fill up first, has %d cards", a.GetNumOfCardsInHand())
+	}
+}
+
+func TestTeamGameRecordsLosingTeamHands(t *testing.T) {
+	// Last card of team 1 goes to bita, both partners of team 2 are left holding sixes
+	options := NewDefaultGameOptions()
+	options.IsTeamGame = true
+	game, err := NewGameFromState(&GameState{
+		Options: options,
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "7C"), Team: 1},
+			{Name: "b", Cards: cardsByCode(t, "6S", "6D"), Team: 2},
+			{Name: "c", IsOut: true, Team: 1},
+			{Name: "d", Cards: cardsByCode(t, "6H"), Team: 2},
+		},
+		KozerCard:           cardsByCode(t, "AS")[0],
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := game.GetPlayerByName("a")
+	b, _ := game.GetPlayerByName("b")
+	if err := game.Attack(a, cardsByCode(t, "7C")...); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Defend(b, &Defence{AttackingCard: cardsByCode(t, "7C")[0], DefendingCard: cardsByCode(t, "6S")[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.MoveToBita(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !game.IsGameOver() || game.GetLosingTeam() != 2 {
+		t.Fatalf("expected team 2 to lose, game over: %v, losing team: %d", game.IsGameOver(), game.GetLosingTeam())
+	}
+	if names := getPlayerNames(game.GetLosingPlayers()); len(names) != 2 || names[0] != "b" || names[1] != "d" {
+		t.Fatalf("expected b and d to lose, got %v", names)
+	}
+
+	hands := game.GetLosingFinalHands()
+	if len(hands["b"]) != 1 || len(hands["d"]) != 1 || len(game.GetLosingPlayerFinalHand()) != 2 {
+		t.Fatalf("unexpected losing hands: %v, whole team: %v", hands, game.GetLosingPlayerFinalHand())
+	}
+	if !game.IsPogony() {
+		t.Fatalf("losing team holding only sixes is pogony")
+	}
+}
+
+func TestDealingForEveryPlayerCount(t *testing.T) {
+	// When the whole deck is dealt, kozer is the last card dealt and there is no stock from the first turn
+
+	for _, deckSize := range []int{24, 36, 52} {
+		maxNumOfPlayers := deckSize / DefaultCardsPerPlayer
+		if maxNumOfPlayers > 8 {
+			maxNumOfPlayers = 8
+		}
+
+		for numOfPlayers := 2; numOfPlayers <= maxNumOfPlayers; numOfPlayers++ {
+			t.Run(fmt.Sprintf("%d cards, %d players", deckSize, numOfPlayers), func(t *testing.T) {
+				checkDealing(t, deckSize, numOfPlayers)
+			})
+		}
+
+		if deckSize/DefaultCardsPerPlayer <= 8 {
+			options := NewDefaultGameOptions()
+			options.DeckSize = deckSize
+			if err := options.Validate(maxNumOfPlayers + 1); !errors.Is(err, ErrInvalidOptions) {
+				t.Fatalf("%d cards must not be enough for %d players, got %v", deckSize, maxNumOfPlayers+1, err)
+			}
+		}
+	}
+}
+
+func checkDealing(t *testing.T, deckSize int, numOfPlayers int) {
+	names := make([]string, 0)
+	for i := 0; i < numOfPlayers; i++ {
+		names = append(names, fmt.Sprintf("player%d", i))
+	}
+
+	options := NewDefaultGameOptions()
+	options.DeckSize = deckSize
+	options.IsKozerSwapAllowed = true
+	options.Seed = int64(deckSize*10 + numOfPlayers)
+	game, err := NewGame(options, names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, player := range game.players {
+		if player.GetNumOfCardsInHand() != DefaultCardsPerPlayer {
+			t.Fatalf("%s was dealt %d cards", player.Name, player.GetNumOfCardsInHand())
+		}
+	}
+
+	numOfCardsLeft := deckSize - numOfPlayers*DefaultCardsPerPlayer
+	if game.GetNumOfCardsLeftInDeck() != numOfCardsLeft {
+		t.Fatalf("expected %d cards in stock, got %d", numOfCardsLeft, game.GetNumOfCardsLeftInDeck())
+	}
+
+	if numOfCardsLeft > 0 {
+		if game.KozerCard != game.deck.PeekLastCard() {
+			t.Fatalf("kozer %s must be the last card in stock", game.KozerCard)
+		}
+		return
+	}
+
+	// Last card dealt went to the last player
+	lastPlayerCards := game.players[numOfPlayers-1].PeekCards()
+	if game.KozerCard == nil || game.KozerCard != lastPlayerCards[len(lastPlayerCards)-1] {
+		t.Fatalf("kozer %s must be the last card dealt, last player holds %v", game.KozerCard, lastPlayerCards)
+	}
+
+	// Kozer card was dealt, it can not be swapped
+	kozerCard := game.KozerCard
+	for _, player := range game.players {
+		if err := game.SwapKozer(player); !errors.Is(err, ErrWrongPhase) {
+			t.Fatalf("swapping kozer with no stock must fail, got %v", err)
+		}
+	}
+	if game.KozerCard != kozerCard {
+		t.Fatalf("kozer changed from %s to %s", kozerCard, game.KozerCard)
+	}
+
+	// First bout ends with defender taking, nobody can fill up
+	startingPlayer := game.GetStartingPlayer()
+	defendingPlayer := game.GetDefendingPlayer()
+	if err := game.Attack(startingPlayer, startingPlayer.PeekCards()[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.DeclareTake(defendingPlayer); err != nil {
+		t.Fatal(err)
+	}
+	for _, player := range game.getEligibleAttackers() {
+		if err := game.Pass(player); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !game.board.IsEmpty() || game.GetNumOfCardsLeftInDeck() != 0 {
+		t.Fatalf("expected cards to be picked up, board: %v, stock: %d", game.board, game.GetNumOfCardsLeftInDeck())
+	}
+	if startingPlayer.GetNumOfCardsInHand() != DefaultCardsPerPlayer-1 ||
+		defendingPlayer.GetNumOfCardsInHand() != DefaultCardsPerPlayer+1 {
+		t.Fatalf("no cards must be filled up, %s has %d cards, %s has %d cards", startingPlayer.Name,
+			startingPlayer.GetNumOfCardsInHand(), defendingPlayer.Name, defendingPlayer.GetNumOfCardsInHand())
+	}
+
+	game.fillUpCards()
+	if startingPlayer.GetNumOfCardsInHand() != DefaultCardsPerPlayer-1 {
+		t.Fatalf("filling up with no stock changed %s's hand", startingPlayer.Name)
+	}
+}
+
+func TestDoubleDeckDealing(t *testing.T) {
+	// Every card of both decks is dealt exactly once, second deck cards are told apart by their code
+	options := NewDefaultGameOptions()
+	options.NumOfDecks = 2
+	options.IsWithJokers = true
+	options.Seed = 12
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	game, err := NewGame(options, names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cards := append([]*Card{}, game.deck.cards...)
+	for _, player := range game.players {
+		cards = append(cards, player.PeekCards()...)
+	}
+	if len(cards) != options.GetNumOfCards() {
+		t.Fatalf("expected %d cards, got %d", options.GetNumOfCards(), len(cards))
+	}
+
+	codes := make(map[string]bool)
+	numOfSecondDeckCards := 0
+	for _, card := range cards {
+		code, err := CardToCode(card)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if codes[code] {
+			t.Fatalf("%s was dealt twice", code)
+		}
+		codes[code] = true
+
+		parsedCard, err := NewCardByCode(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !parsedCard.IsSameCard(card) {
+			t.Fatalf("%s parsed as %v", code, parsedCard)
+		}
+		if card.DeckIndex == 1 {
+			numOfSecondDeckCards++
+		}
+	}
+	if numOfSecondDeckCards != options.GetNumOfCards()/2 {
+		t.Fatalf("expected %d second deck cards, got %d", options.GetNumOfCards()/2, numOfSecondDeckCards)
+	}
+}
+
+func TestPeekedCardsDoNotChangeWithGame(t *testing.T) {
+	// Responses are built from peeked cards and sent after the game goes on
+	game, err := NewGameFromState(&GameState{
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "7H", "9D")},
+			{Name: "b", Cards: cardsByCode(t, "9H", "10H")},
+		},
+		Deck:                cardsByCode(t, "6D", "AS"),
+		KozerCard:           cardsByCode(t, "AS")[0],
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := game.GetPlayerByName("a")
+	b, _ := game.GetPlayerByName("b")
+	if err := game.Attack(a, cardsByCode(t, "7H")...); err != nil {
+		t.Fatal(err)
+	}
+	playerCards := game.GetPlayersCardsMap()
+	cardsOnBoard := game.GetCardsOnBoard()
+
+	if err := game.Defend(b, &Defence{AttackingCard: cardsByCode(t, "7H")[0], DefendingCard: cardsByCode(t, "9H")[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Attack(a, cardsByCode(t, "9D")...); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(playerCards["a"]) != 1 || !playerCards["a"][0].IsSameCard(cardsByCode(t, "9D")[0]) || len(playerCards["b"]) != 2 {
+		t.Fatalf("peeked hands changed: %v", playerCards)
+	}
+	if len(cardsOnBoard) != 1 || cardsOnBoard[0].GetDefendingCard() != nil {
+		t.Fatalf("peeked board changed: %v", cardsOnBoard)
+	}
+}
+
+func newMultiCardGame(t *testing.T) (*Game, *Player, *Player) {
+	// a attacks b, kozer is spades
+	t.Helper()
+	game, err := NewGameFromState(&GameState{
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "7C", "7D", "7S", "8H", "9C")},
+			{Name: "b", Cards: cardsByCode(t, "8C", "8D", "10S", "6H", "9H", "6S")},
+		},
+		KozerCard:           cardsByCode(t, "AS")[0],
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := game.GetPlayerByName("a")
+	b, _ := game.GetPlayerByName("b")
+	return game, a, b
+}
+
+func TestMultiCardAttackIsAllOrNothing(t *testing.T) {
+	tests := []struct {
+		name  string
+		codes []string
+		err   *GameError
+	}{
+		{name: "different values", codes: []string{"7C", "7D", "8H"}, err: ErrCannotAdd},
+		{name: "card not in hand", codes: []string{"7C", "7H"}, err: ErrCardNotInHand},
+		{name: "same card twice", codes: []string{"7C", "7D", "7C"}, err: ErrInvalidCard},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game, a, b := newMultiCardGame(t)
+			position := fmt.Sprint(a.PeekCards(), b.PeekCards(), game.board)
+
+			if err := game.Attack(a, cardsByCode(t, test.codes...)...); !errors.Is(err, test.err) {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+			if fmt.Sprint(a.PeekCards(), b.PeekCards(), game.board) != position || game.GetPhase() != PhaseAttacking {
+				t.Fatalf("position changed from %s to %s", position, fmt.Sprint(a.PeekCards(), b.PeekCards(), game.board))
+			}
+		})
+	}
+
+	game, a, _ := newMultiCardGame(t)
+	if err := game.Attack(a, cardsByCode(t, "7C", "7D", "7S")...); err != nil {
+		t.Fatal(err)
+	}
+	if game.board.NumOfAttackingCards() != 3 || a.GetNumOfCardsInHand() != 2 {
+		t.Fatalf("expected all three cards on board, board %v, %s holds %v", game.board, a.Name, a.PeekCards())
+	}
+}
+
+func TestMultiCardDefenceIsAllOrNothing(t *testing.T) {
+	defence := func(attackingCode string, defendingCode string) *Defence {
+		return &Defence{AttackingCard: cardsByCode(t, attackingCode)[0], DefendingCard: cardsByCode(t, defendingCode)[0]}
+	}
+	tests := []struct {
+		name     string
+		defences []*Defence
+		err      *GameError
+	}{
+		{name: "card can not beat", defences: []*Defence{defence("7C", "8C"), defence("7D", "6H")}, err: ErrCannotBeat},
+		{name: "card not in hand", defences: []*Defence{defence("7C", "8C"), defence("7D", "9D")}, err: ErrCardNotInHand},
+		{name: "card not on board", defences: []*Defence{defence("7C", "8C"), defence("7S", "10S")}, err: ErrInvalidCard},
+		{name: "card used twice", defences: []*Defence{defence("7C", "6S"), defence("7D", "6S")}, err: ErrInvalidCard},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game, a, b := newMultiCardGame(t)
+			if err := game.Attack(a, cardsByCode(t, "7C", "7D")...); err != nil {
+				t.Fatal(err)
+			}
+			position := fmt.Sprint(a.PeekCards(), b.PeekCards(), game.board)
+
+			if err := game.Defend(b, test.defences...); !errors.Is(err, test.err) {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+			if fmt.Sprint(a.PeekCards(), b.PeekCards(), game.board) != position || game.GetPhase() != PhaseDefending {
+				t.Fatalf("position changed from %s to %s", position, fmt.Sprint(a.PeekCards(), b.PeekCards(), game.board))
+			}
+		})
+	}
+
+	game, a, b := newMultiCardGame(t)
+	if err := game.Attack(a, cardsByCode(t, "7C", "7D")...); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Defend(b, defence("7C", "8C"), defence("7D", "6S")); err != nil {
+		t.Fatal(err)
+	}
+	if !game.board.AreAllCardsDefended() || b.GetNumOfCardsInHand() != 4 {
+		t.Fatalf("expected both cards to be defended, board %v, %s holds %v", game.board, b.Name, b.PeekCards())
+	}
+}
+
+func TestMovesAfterGameOver(t *testing.T) {
+	options := NewDefaultGameOptions()
+	options.Variant = Perevodnoy
+	options.IsKozerSwapAllowed = true
+	game, err := NewGameFromState(&GameState{
+		Options: options,
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "7C")},
+			{Name: "b", Cards: cardsByCode(t, "8C", "9S", "6S")},
+		},
+		KozerCard:           cardsByCode(t, "AS")[0],
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := game.GetPlayerByName("a")
+	b, _ := game.GetPlayerByName("b")
+	if err := game.Attack(a, cardsByCode(t, "7C")...); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.Defend(b, &Defence{AttackingCard: cardsByCode(t, "7C")[0], DefendingCard: cardsByCode(t, "8C")[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := game.MoveToBita(); err != nil {
+		t.Fatal(err)
+	}
+	if !game.IsGameOver() || game.GetPhase() != PhaseGameOver || game.IsAbandoned() {
+		t.Fatalf("expected game to be played out, phase is %s, abandoned: %v", game.GetPhase(), game.IsAbandoned())
+	}
+
+	moves := map[string]func() error{
+		"attack":   func() error { return game.Attack(b, cardsByCode(t, "9S")...) },
+		"defend":   func() error { return game.Defend(b, &Defence{AttackingCard: cardsByCode(t, "7C")[0], DefendingCard: cardsByCode(t, "9S")[0]}) },
+		"transfer": func() error { return game.Transfer(b, cardsByCode(t, "9S")[0]) },
+		"pass":     func() error { return game.Pass(b) },
+		"take":     func() error { return game.DeclareTake(b) },
+		"bita":     func() error { return game.MoveToBita() },
+		"swap":     func() error { return game.SwapKozer(b) },
+		"redeal":   func() error { return game.RequestRedeal(b) },
+	}
+	for name, move := range moves {
+		if err := move(); !errors.Is(err, ErrWrongPhase) {
+			t.Errorf("%s after game over must fail with %v, got %v", name, ErrWrongPhase, err)
+		}
+	}
+
+	if len(b.PeekCards()) != 2 || !game.board.IsEmpty() {
+		t.Fatalf("position changed after game over, %s holds %v, board %v", b.Name, b.PeekCards(), game.board)
+	}
+}
+
+func newGameWithKozerSixDealt(t *testing.T) (*Game, *Player) {
+	// Seeded game where a player holds the six of kozer, returned with that player
+	t.Helper()
+	for seed := int64(1); seed < 100; seed++ {
+		options := NewDefaultGameOptions()
+		options.IsKozerSwapAllowed = true
+		options.Seed = seed
+		game, err := NewGame(options, "a", "b")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		kozerSix, _ := NewCard(game.KozerCard.Kind, 6)
+		for _, player := range game.players {
+			if player.HasCard(kozerSix) {
+				return game, player
+			}
+		}
+	}
+	t.Fatal("six of kozer was not dealt with any seed")
+	return nil, nil
+}
+
+func TestSwapKozer(t *testing.T) {
+	game, holder := newGameWithKozerSixDealt(t)
+	kozerCard := game.KozerCard
+	kozerSix, _ := NewCard(kozerCard.Kind, 6)
+
+	if err := game.SwapKozer(holder.NextPlayer); !errors.Is(err, ErrCardNotInHand) {
+		t.Fatalf("only the six of kozer can be swapped, got %v", err)
+	}
+	if err := game.SwapKozer(holder); err != nil {
+		t.Fatal(err)
+	}
+	if !game.KozerCard.IsSameCard(kozerSix) || !game.deck.PeekLastCard().IsSameCard(kozerSix) || !holder.HasCard(kozerCard) {
+		t.Fatalf("expected %s to be swapped with %s, kozer is %s", kozerSix, kozerCard, game.KozerCard)
+	}
+}
+
+func TestSwapKozerAfterFirstAttack(t *testing.T) {
+	game, holder := newGameWithKozerSixDealt(t)
+	kozerSix, _ := NewCard(game.KozerCard.Kind, 6)
+
+	startingPlayer := game.GetStartingPlayer()
+	for _, card := range startingPlayer.PeekCards() {
+		if !card.IsSameCard(kozerSix) {
+			if err := game.Attack(startingPlayer, card); err != nil {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+
+	if err := game.SwapKozer(holder); !errors.Is(err, ErrWrongPhase) {
+		t.Fatalf("kozer can not be swapped after first attack, got %v", err)
+	}
+	if !holder.HasCard(kozerSix) {
+		t.Fatalf("%s must still hold %s", holder.Name, kozerSix)
+	}
+}
+
+func newGameWithRedealPlayer(t *testing.T) (*Game, *Player) {
+	// Seeded game where a player was dealt no kozer, returned with that player
+	t.Helper()
+	for seed := int64(1); seed < 100; seed++ {
+		options := NewDefaultGameOptions()
+		options.IsRedealOnNoKozer = true
+		options.Seed = seed
+		game, err := NewGame(options, "a", "b", "c")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, player := range game.players {
+			if game.redealPlayers[player] {
+				return game, player
+			}
+		}
+	}
+	t.Fatal("no player was dealt a hand without kozer with any seed")
+	return nil, nil
+}
+
+func TestRedeal(t *testing.T) {
+	game, player := newGameWithRedealPlayer(t)
+	for _, otherPlayer := range game.players {
+		if !game.redealPlayers[otherPlayer] {
+			if err := game.RequestRedeal(otherPlayer); !errors.Is(err, ErrNotAllowed) {
+				t.Fatalf("%s holds kozer and can not ask for a redeal, got %v", otherPlayer.Name, err)
+			}
+		}
+	}
+
+	if err := game.RequestRedeal(player); err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range game.players {
+		if p.GetNumOfCardsInHand() != DefaultCardsPerPlayer {
+			t.Fatalf("%s was dealt %d cards on redeal", p.Name, p.GetNumOfCardsInHand())
+		}
+	}
+	if game.GetNumOfCardsLeftInDeck() != DefaultDeckSize-3*DefaultCardsPerPlayer {
+		t.Fatalf("expected %d cards in stock after redeal, got %d", DefaultDeckSize-3*DefaultCardsPerPlayer,
+			game.GetNumOfCardsLeftInDeck())
+	}
+}
+
+func TestRedealAfterFirstAttack(t *testing.T) {
+	game, player := newGameWithRedealPlayer(t)
+	startingPlayer := game.GetStartingPlayer()
+	if err := game.Attack(startingPlayer, startingPlayer.PeekCards()[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	cards := player.PeekCards()
+	if err := game.RequestRedeal(player); !errors.Is(err, ErrNotAllowed) {
+		t.Fatalf("redeal after first attack must fail with %v, got %v", ErrNotAllowed, err)
+	}
+	if len(game.GetRedealPlayerNames()) != 0 {
+		t.Fatalf("no player may ask for a redeal after first attack, got %v", game.GetRedealPlayerNames())
+	}
+	for i, card := range player.PeekCards() {
+		if card != cards[i] {
+			t.Fatalf("%s's hand changed from %v to %v", player.Name, cards, player.PeekCards())
+		}
+	}
+}
+
+func TestPlayerLeavingEndsGame(t *testing.T) {
+	game, err := NewGameFromState(&GameState{
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "7C", "8C")},
+			{Name: "b", Cards: cardsByCode(t, "6S", "6D")},
+		},
+		Deck:                cardsByCode(t, "9D", "AS"),
+		KozerCard:           cardsByCode(t, "AS")[0],
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := game.HandlePlayerLeft("a"); err != nil {
+		t.Fatal(err)
+	}
+	if !game.IsGameOver() || !game.IsAbandoned() {
+		t.Fatalf("game ended by a player leaving must be abandoned, game over: %v, abandoned: %v",
+			game.IsGameOver(), game.IsAbandoned())
+	}
+	if game.GetLosingPlayerName() != "" || len(game.GetLosingPlayerFinalHand()) != 0 || game.IsPogony() {
+		t.Fatalf("nobody loses an abandoned game, %s lost with %v", game.GetLosingPlayerName(), game.GetLosingPlayerFinalHand())
+	}
+	if !restoreSnapshot(t, game).IsAbandoned() {
+		t.Fatalf("abandoned game restored as played out")
+	}
+}
+
+func TestMovesAfterPlayersLeft(t *testing.T) {
+	// Last player seated can not play on alone
+	game, err := NewGameFromState(&GameState{
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "7C", "8C")},
+			{Name: "b", Cards: cardsByCode(t, "9H", "10H")},
+			{Name: "c", Cards: cardsByCode(t, "JH", "QH")},
+		},
+		Deck:                cardsByCode(t, "9D", "AS"),
+		KozerCard:           cardsByCode(t, "AS")[0],
+		Board:               []*CardOnBoardState{{AttackingCard: cardsByCode(t, "7D")[0], AttackerName: "a"}},
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"a", "c"} {
+		if err := game.HandlePlayerLeft(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	b, _ := game.GetPlayerByName("b")
+	if !game.IsGameOver() || game.GetStartingPlayer() == b {
+		t.Fatalf("%s must not be put on turn once game is over, game over: %v", b.Name, game.IsGameOver())
+	}
+
+	if err := game.Attack(b, cardsByCode(t, "9H")...); !errors.Is(err, ErrWrongPhase) {
+		t.Fatalf("attack after game over must fail with %v, got %v", ErrWrongPhase, err)
+	}
+	if err := game.DeclareTake(b); !errors.Is(err, ErrWrongPhase) {
+		t.Fatalf("take after game over must fail with %v, got %v", ErrWrongPhase, err)
+	}
+	if len(game.LegalMoves(b)) != 0 {
+		t.Fatalf("%s must have no legal moves, got %v", b.Name, game.LegalMoves(b))
+	}
+	if len(b.PeekCards()) != 2 || !game.board.IsEmpty() {
+		t.Fatalf("position changed after game over, %s holds %v, board %v", b.Name, b.PeekCards(), game.board)
+	}
+	checkSameGame(t, game, restoreSnapshot(t, game))
+}
@@ -14,7 +14,14 @@ const (
 	Diamonds = Kind("Diamonds")
 )
 
+// Jokers are not a suit, each joker kind is used for a single card
+const (
+	RedJoker   = Kind("Red Joker")
+	BlackJoker = Kind("Black Joker")
+)
+
 var Kinds    = []Kind{Clubs, Spades, Hearts, Diamonds}
+var JokerKinds = []Kind{RedJoker, BlackJoker}
 
 func GetKindCode(kind Kind) (byte, error) {
 	for _, k := range Kinds {
@@ -23,9 +30,22 @@ func GetKindCode(kind Kind) (byte, error) {
 		}
 	}
 
+	if IsJokerKind(kind) {
+		return kind[0], nil
+	}
+
 	return 0, errors.New("unknown kind")
 }
 
+func IsJokerKind(kind Kind) bool {
+	for _, k := range JokerKinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
+
 func GetCardKindByCode(kindCode string) (Kind, error) {
 	switch kindCode {
 	case "C":
@@ -36,6 +56,10 @@ func GetCardKindByCode(kindCode string) (Kind, error) {
 		return Diamonds, nil
 	case "H":
 		return Hearts, nil
+	case "R":
+		return RedJoker, nil
+	case "B":
+		return BlackJoker, nil
 	default:
 		return "", fmt.Errorf("no such kind code: %v", kindCode)
 	}
@@ -0,0 +1,62 @@
+package game
+
+// Legal moves of a player, found with the same validations the moves themselves use
+
+type MoveType string
+
+const (
+	MoveAttack   = MoveType("attack")  // Opening an attack or adding a card to it
+	MoveDefend   = MoveType("defend")
+	MoveTransfer = MoveType("transfer")
+	MovePass     = MoveType("pass")
+	MoveTake     = MoveType("take")
+	MoveBita     = MoveType("bita")
+)
+
+type Move struct {
+	Type    MoveType `json:"type"`
+	Card    *Card    `json:"card"`  // Attack and transfer
+	Defence *Defence `json:"defence"`  // Defend
+}
+
+func (this *Game) LegalMoves(player *Player) []*Move {
+	// Attacks are listed card by card, cards of the same value that are all legal may also be played together.
+	// In shuler games only moves that follow the rules are listed
+
+	moves := make([]*Move, 0)
+	if !player.IsPlaying || this.IsGameOver() {
+		return moves
+	}
+
+	for _, card := range player.PeekCards() {
+		cards := []*Card{card}
+		if this.validateAttack(player, cards) == nil && this.checkAttackRules(cards) == nil {
+			moves = append(moves, &Move{Type: MoveAttack, Card: card})
+		}
+
+		if this.validateTransfer(player, card) == nil {
+			moves = append(moves, &Move{Type: MoveTransfer, Card: card})
+		}
+
+		for _, attackingCard := range this.board.peekUndefendedCards() {
+			defences := []*Defence{{AttackingCard: attackingCard, DefendingCard: card}}
+			if this.validateDefence(player, defences) == nil && this.checkDefenceRules(defences) == nil {
+				moves = append(moves, &Move{Type: MoveDefend, Defence: defences[0]})
+			}
+		}
+	}
+
+	if this.validatePass(player) == nil {
+		moves = append(moves, &Move{Type: MovePass})
+	}
+
+	if this.validateTake(player) == nil {
+		moves = append(moves, &Move{Type: MoveTake})
+	}
+
+	if this.validateBita() == nil {
+		moves = append(moves, &Move{Type: MoveBita})
+	}
+
+	return moves
+}
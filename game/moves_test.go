@@ -0,0 +1,61 @@
+package game
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestLegalMovesAreAccepted(t *testing.T) {
+	for _, variant := range []Variant{Podkidnoy, Perevodnoy} {
+		t.Run(string(variant), func(t *testing.T) {
+			options := NewDefaultGameOptions()
+			options.Variant = variant
+			options.IsNeighboursOnly = variant == Podkidnoy
+			options.Seed = 11
+			game, err := NewGame(options, "a", "b", "c")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			random := rand.New(rand.NewSource(11))
+			for numOfMoves := 0; !game.IsGameOver(); numOfMoves++ {
+				if numOfMoves > 1000 {
+					t.Fatal("game is not over after 1000 moves")
+				}
+
+				// Every move is played on its own copy of the position
+				for _, player := range game.players {
+					for _, move := range game.LegalMoves(player) {
+						gameCopy, err := NewGameFromState(game.GetState())
+						if err != nil {
+							t.Fatal(err)
+						}
+						playerCopy, _ := gameCopy.GetPlayerByName(player.Name)
+						if err := playMove(gameCopy, playerCopy, move); err != nil {
+							t.Fatalf("legal move %s of %s was refused in phase %s: %s", describeMove(move), player.Name,
+								game.GetPhase(), err)
+						}
+					}
+				}
+				playRandomMove(t, game, random)
+			}
+
+			for _, player := range game.players {
+				if moves := game.LegalMoves(player); len(moves) != 0 {
+					t.Fatalf("%s has %d legal moves after game over", player.Name, len(moves))
+				}
+			}
+		})
+	}
+}
+
+func describeMove(move *Move) string {
+	switch {
+	case move.Card != nil:
+		return fmt.Sprintf("%s %s", move.Type, move.Card)
+	case move.Defence != nil:
+		return fmt.Sprintf("%s %s with %s", move.Type, move.Defence.AttackingCard, move.Defence.DefendingCard)
+	}
+	return string(move.Type)
+}
@@ -0,0 +1,153 @@
+package game
+
+type StartingPlayerRule string
+
+const (
+	LowestKozerStarts  = StartingPlayerRule("lowestKozer")
+	FirstPlayerStarts  = StartingPlayerRule("firstPlayer")
+	RandomPlayerStarts = StartingPlayerRule("random")
+
+	// Rules using previous game of a match, first game falls back to lowest kozer
+	PreviousDurakStarts = StartingPlayerRule("previousDurak")
+	LeftOfDurakStarts   = StartingPlayerRule("leftOfDurak")
+)
+
+var StartingPlayerRules = []StartingPlayerRule{LowestKozerStarts, FirstPlayerStarts, RandomPlayerStarts,
+	PreviousDurakStarts, LeftOfDurakStarts}
+
+const (
+	DefaultCardsPerPlayer    = 6
+	DefaultMaxCardsPerAttack = 6
+	DefaultDeckSize          = 36
+)
+
+type GameOptions struct {
+	CardsPerPlayer    int                `json:"cardsPerPlayer"`
+	MaxCardsPerAttack int                `json:"maxCardsPerAttack"`
+	DeckSize          int                `json:"deckSize"`
+	StartingPlayer    StartingPlayerRule `json:"startingPlayer"`
+	Variant           Variant            `json:"variant"`
+	IsNeighboursOnly  bool               `json:"isNeighboursOnly"`
+	IsTeamGame        bool               `json:"isTeamGame"`
+	IsKozerSwapAllowed bool              `json:"isKozerSwapAllowed"`
+	IsWithJokers      bool               `json:"isWithJokers"`
+	NumOfDecks        int                `json:"numOfDecks"`
+	IsShuler          bool               `json:"isShuler"`  // Illegal moves are accepted, other players may call cheat
+	Seed              int64              `json:"seed,omitempty"`  // Shuffling seed, to reproduce a game. 0 for a random seed
+
+	// Redeal rules, players with such starting hands can ask for a redeal
+	RedealSameKindCount int  `json:"redealSameKindCount"`  // 0 for no redeal
+	IsRedealOnNoKozer   bool `json:"isRedealOnNoKozer"`
+
+	// Losing player of previous game in match, filled by whoever runs the match
+	PreviousDurakName string `json:"previousDurakName"`
+
+	// Custom rule set (house rules), overrides variant when set
+	Rules RuleSet `json:"-"`
+}
+
+func NewDefaultGameOptions() *GameOptions {
+	return &GameOptions{
+		CardsPerPlayer:    DefaultCardsPerPlayer,
+		MaxCardsPerAttack: DefaultMaxCardsPerAttack,
+		DeckSize:          DefaultDeckSize,
+		StartingPlayer:    LowestKozerStarts,
+		Variant:           Podkidnoy,
+		IsNeighboursOnly:  false,
+		IsTeamGame:        false,
+		IsKozerSwapAllowed: false,
+		IsWithJokers:      false,
+		NumOfDecks:        1,
+		IsShuler:          false,
+		Seed:              0,
+		RedealSameKindCount: 0,
+		IsRedealOnNoKozer: false,
+	}
+}
+
+func (this *GameOptions) Validate(numOfPlayers int) error {
+	if this.CardsPerPlayer < 1 {
+		return newGameError(ErrInvalidOptions, "players must be dealt at least one card")
+	}
+
+	if this.MaxCardsPerAttack < 1 {
+		return newGameError(ErrInvalidOptions, "attacking cards limit must be at least one")
+	}
+
+	if _, err := this.GetMinCardValue(); err != nil {
+		return err
+	}
+
+	if this.NumOfDecks < 1 || this.NumOfDecks > 2 {
+		return newGameError(ErrInvalidOptions, "games are played with one or two decks")
+	}
+
+	if this.RedealSameKindCount < 0 {
+		return newGameError(ErrInvalidOptions, "redeal same kind count can not be negative")
+	}
+
+	if this.IsTeamGame && numOfPlayers != 4 {
+		return newGameError(ErrInvalidOptions, "team games are played by exactly 4 players")
+	}
+
+	// Whole deck may be dealt, kozer is then the last card dealt
+	if numOfPlayers*this.CardsPerPlayer > this.GetNumOfCards() {
+		return newGameError(ErrInvalidOptions, "%d cards are not enough to deal %d cards to %d players",
+			this.GetNumOfCards(), this.CardsPerPlayer, numOfPlayers)
+	}
+
+	if _, err := this.GetRuleSet(); err != nil {
+		return err
+	}
+
+	for _, rule := range StartingPlayerRules {
+		if rule == this.StartingPlayer {
+			return nil
+		}
+	}
+	return newGameError(ErrInvalidOptions, "unknown starting player rule: %s", this.StartingPlayer)
+}
+
+func (this *GameOptions) GetMinCardValue() (uint, error) {
+	// Lowest card value in deck, derived from deck size
+	switch this.DeckSize {
+	case 24:
+		return 9, nil
+	case 36:
+		return 6, nil
+	case 52:
+		return 2, nil
+	default:
+		return 0, newGameError(ErrInvalidOptions, "deck size must be 24, 36 or 52, not %d", this.DeckSize)
+	}
+}
+
+func (this *GameOptions) GetNumOfCards() int {
+	// Size of all decks including jokers
+	if this.IsWithJokers {
+		return (this.DeckSize + len(JokerKinds)) * this.NumOfDecks
+	}
+	return this.DeckSize * this.NumOfDecks
+}
+
+func (this *GameOptions) GetRuleSet() (RuleSet, error) {
+	var rules RuleSet
+	if this.Rules != nil {
+		rules = this.Rules
+	} else {
+		variantRules, err := NewRuleSet(this.Variant)
+		if err != nil {
+			return nil, err
+		}
+		rules = variantRules
+	}
+
+	if this.IsNeighboursOnly {
+		return NewNeighboursOnlyRules(rules), nil
+	}
+	return rules, nil
+}
+
+func (this *GameOptions) GetMaxCardValue() uint {
+	return MaxCardValue
+}
@@ -0,0 +1,93 @@
+package game
+
+type Phase string
+
+const (
+	// Waiting for an attack - opening one on an empty board, or a throw-in once all cards are defended
+	PhaseAttacking = Phase("attacking")
+
+	// Some cards on board are not defended yet
+	PhaseDefending = Phase("defending")
+
+	// Defending player declared taking, attackers may still add cards before pick up
+	PhaseDefenderTaking = Phase("defenderTaking")
+
+	// All cards are defended and no attacker is adding more, cards can go to bita
+	PhaseBoutComplete = Phase("boutComplete")
+
+	// Game is over, no more moves can be made
+	PhaseGameOver = Phase("gameOver")
+)
+
+func (this *Game) GetPhase() Phase {
+	return this.phase
+}
+
+func (this *Game) GetPassedPlayerNames() []string {
+	names := make([]string, 0)
+	for _, player := range this.players {
+		if this.passedPlayers[player] {
+			names = append(names, player.Name)
+		}
+	}
+	return names
+}
+
+func (this *Game) updatePhase() {
+	// Derives current phase from board and attackers state
+
+	if this.IsGameOver() {
+		this.phase = PhaseGameOver
+	} else if this.board.IsEmpty() {
+		this.phase = PhaseAttacking
+	} else if this.isTakeDeclared {
+		this.phase = PhaseDefenderTaking
+	} else if !this.board.AreAllCardsDefended() {
+		this.phase = PhaseDefending
+	} else if !this.canMoreCardsBeAdded() || this.haveAllAttackersPassed() {
+		this.phase = PhaseBoutComplete
+	} else {
+		this.phase = PhaseAttacking
+	}
+}
+
+func (this *Game) validateGameNotOver() error {
+	if this.IsGameOver() {
+		return newGameError(ErrWrongPhase, "game is over")
+	}
+	return nil
+}
+
+func (this *Game) resetPasses() {
+	this.passedPlayers = make(map[*Player]bool)
+}
+
+func (this *Game) haveAllAttackersPassed() bool {
+	for _, player := range this.getEligibleAttackers() {
+		if !this.passedPlayers[player] {
+			return false
+		}
+	}
+	return true
+}
+
+func (this *Game) canMoreCardsBeAdded() bool {
+	// Checks attack limits only, not whether anyone holds a matching card
+
+	if this.board.NumOfAttackingCards() >= this.options.MaxCardsPerAttack {
+		return false
+	}
+	return len(this.board.peekUndefendedCards()) < this.defendingPlayer.GetNumOfCardsInHand()
+}
+
+func (this *Game) getEligibleAttackers() []*Player {
+	// Players that are allowed to add cards now and have cards to add
+
+	players := make([]*Player, 0)
+	for player := this.defendingPlayer.NextPlayer; player != this.defendingPlayer; player = player.NextPlayer {
+		if player.GetNumOfCardsInHand() > 0 && this.canPlayerAttackNow(player) {
+			players = append(players, player)
+		}
+	}
+	return players
+}
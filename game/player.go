@@ -1,19 +1,17 @@
 package game
 
-import (
-	"errors"
-	"fmt"
-)
+import "fmt"
 
 type Player struct {
 	cards []*Card
 	Name string
 	IsPlaying bool
 	NextPlayer *Player
+	Team int  // 0 when not playing in teams
 }
 
 func NewPlayer(name string) *Player {
-	return &Player{cards: make([]*Card, 0), Name: name}
+	return &Player{cards: make([]*Card, 0), Name: name, IsPlaying: true}
 }
 
 func (this *Player) TakeCards(cards ...*Card) {
@@ -24,24 +22,47 @@ func (this *Player) TakeCards(cards ...*Card) {
 func (this *Player) GetCard(card *Card) (*Card, error) {
 	// Gets a specific card and removes card from hand
 	for i, currentCard := range this.cards {
-		if currentCard.Value == card.Value && currentCard.Kind == card.Kind {
+		if currentCard.IsSameCard(card) {
 			this.cards = append(this.cards[:i], this.cards[i+1:]...)
 			return currentCard, nil
 		}
 	}
-	return nil, errors.New("no such card in player's hand")
+	return nil, newGameError(ErrCardNotInHand, "no such card in player's hand")
+}
+
+func (this *Player) HasCard(card *Card) bool {
+	for _, currentCard := range this.cards {
+		if currentCard.IsSameCard(card) {
+			return true
+		}
+	}
+	return false
+}
+
+func (this *Player) GetCardOfAnyDeck(card *Card) (*Card, error) {
+	// Like GetCard, but any copy of the card will do when playing with more than one deck
+	for _, currentCard := range this.cards {
+		if currentCard.Value == card.Value && currentCard.Kind == card.Kind {
+			return this.GetCard(currentCard)
+		}
+	}
+	return nil, newGameError(ErrCardNotInHand, "no such card in player's hand")
 }
 
 func (this *Player) PeekCards() []*Card {
 	// Returns all cards
-	// Does NOT remove them from hand
-	return this.cards
+	// Does NOT remove them from hand, returned slice does not change with the hand
+	return append([]*Card{}, this.cards...)
 }
 
 func (this *Player) GetNumOfCardsInHand() int {
 	return len(this.cards)
 }
 
+func (this *Player) clearHand() {
+	this.cards = make([]*Card, 0)
+}
+
 func (this *Player) String() string {
 	return fmt.Sprintf("%v: %v", this.Name, this.cards)
 }
\ No newline at end of file
@@ -0,0 +1,33 @@
+package game
+
+import "math/rand"
+
+// Random source of a game, counts its draws so the random state can be saved as seed and number of draws
+
+type countingSource struct {
+	source     rand.Source64
+	numOfDraws uint64
+}
+
+func newGameRandom(seed int64, numOfDraws uint64) (*rand.Rand, *countingSource) {
+	source := &countingSource{source: rand.NewSource(seed).(rand.Source64)}
+	for source.numOfDraws < numOfDraws {
+		source.Uint64()
+	}
+	return rand.New(source), source
+}
+
+func (this *countingSource) Int63() int64 {
+	this.numOfDraws++
+	return this.source.Int63()
+}
+
+func (this *countingSource) Uint64() uint64 {
+	this.numOfDraws++
+	return this.source.Uint64()
+}
+
+func (this *countingSource) Seed(seed int64) {
+	this.numOfDraws = 0
+	this.source.Seed(seed)
+}
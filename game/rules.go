@@ -0,0 +1,135 @@
+package game
+
+// Rules that differ between durak variants
+// Game only asks the rule set, it never decides on these by itself
+
+type RuleSet interface {
+	// Checks if a player has the right to attack with a card
+	CanPlayerAttackNow(game *Game, player *Player) bool
+
+	// Checks if a card can be thrown in on a non empty board
+	CanCardBeAdded(board *Board, card *Card) bool
+
+	// Checks if defending card beats attacking card
+	CanCardDefend(defendingCard *Card, attackingCard *Card, kozerKind Kind) bool
+
+	// Checks if defending player can pass the attack on with this card
+	CanCardBeTransferred(board *Board, card *Card) bool
+
+	// Returns starting and defending players of the next turn
+	GetNextTurnPlayers(game *Game, wasLastTurnDefended bool) (*Player, *Player)
+
+	// Returns players in the order they fill up cards from the deck
+	GetFillUpOrder(game *Game) []*Player
+}
+
+type Variant string
+
+const (
+	Podkidnoy  = Variant("podkidnoy")
+	Perevodnoy = Variant("perevodnoy")
+)
+
+func NewRuleSet(variant Variant) (RuleSet, error) {
+	switch variant {
+	case Podkidnoy:
+		return &PodkidnoyRules{}, nil
+	case Perevodnoy:
+		return &PerevodnoyRules{}, nil
+	default:
+		return nil, newGameError(ErrInvalidOptions, "unknown variant: %s", variant)
+	}
+}
+
+// Podkidnoy - classic throw-in durak
+
+type PodkidnoyRules struct{}
+
+func (this *PodkidnoyRules) CanPlayerAttackNow(game *Game, player *Player) bool {
+	// Starting player opens the attack, after that anyone but the defender can add
+	if game.board.IsEmpty() {
+		return game.GetStartingPlayer() == player
+	} else {
+		return player != game.GetDefendingPlayer()
+	}
+}
+
+func (this *PodkidnoyRules) CanCardBeAdded(board *Board, card *Card) bool {
+	return board.CanCardBeAdded(card)
+}
+
+func (this *PodkidnoyRules) CanCardDefend(defendingCard *Card, attackingCard *Card, kozerKind Kind) bool {
+	return defendingCard.CanDefendCard(attackingCard, &kozerKind)
+}
+
+func (this *PodkidnoyRules) CanCardBeTransferred(board *Board, card *Card) bool {
+	return false
+}
+
+func (this *PodkidnoyRules) GetNextTurnPlayers(game *Game, wasLastTurnDefended bool) (*Player, *Player) {
+	// Successful defender attacks next, otherwise the player after the defender does
+	var startingPlayer *Player
+	defendingPlayer := game.GetDefendingPlayer()
+
+	if wasLastTurnDefended && defendingPlayer.GetNumOfCardsInHand() > 0 {
+		startingPlayer = defendingPlayer
+	} else {
+		startingPlayer = defendingPlayer.NextPlayer
+	}
+
+	return startingPlayer, startingPlayer.NextPlayer
+}
+
+func (this *PodkidnoyRules) GetFillUpOrder(game *Game) []*Player {
+	// Starting player fills up first, then around the table, defending player last
+	startingPlayer := game.GetStartingPlayer()
+	defendingPlayer := game.GetDefendingPlayer()
+	players := make([]*Player, 0)
+
+	player := startingPlayer
+	for {
+		if player != defendingPlayer {
+			players = append(players, player)
+		}
+		player = player.NextPlayer
+		if player == startingPlayer {
+			break
+		}
+	}
+
+	return append(players, defendingPlayer)
+}
+
+// Perevodnoy - defending player can transfer the attack before defending any card
+
+type PerevodnoyRules struct {
+	PodkidnoyRules
+}
+
+func (this *PerevodnoyRules) CanCardBeTransferred(board *Board, card *Card) bool {
+	return board.CanCardBeTransferred(card)
+}
+
+// Neighbours only - only the players sitting next to the defender can throw in
+// Wraps any other rule set
+
+type NeighboursOnlyRules struct {
+	RuleSet
+}
+
+func NewNeighboursOnlyRules(rules RuleSet) *NeighboursOnlyRules {
+	return &NeighboursOnlyRules{RuleSet: rules}
+}
+
+func (this *NeighboursOnlyRules) CanPlayerAttackNow(game *Game, player *Player) bool {
+	if !this.RuleSet.CanPlayerAttackNow(game, player) {
+		return false
+	}
+
+	if game.board.IsEmpty() {
+		return true
+	}
+
+	defendingPlayer := game.GetDefendingPlayer()
+	return player == defendingPlayer.NextPlayer || player == game.getPreviousPlayer(defendingPlayer)
+}
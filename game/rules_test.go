@@ -0,0 +1,265 @@
+package game
+
+import "testing"
+
+func newNeighboursOnlyGame(t *testing.T, players []*PlayerState, defendingPlayerName string) *Game {
+	// Starting player "a" opened the attack on defending player
+	t.Helper()
+	options := NewDefaultGameOptions()
+	options.IsNeighboursOnly = true
+	game, err := NewGameFromState(&GameState{
+		Options:             options,
+		Players:             players,
+		KozerCard:           cardsByCode(t, "AS")[0],
+		Board:               []*CardOnBoardState{{AttackingCard: cardsByCode(t, "6C")[0], AttackerName: "a"}},
+		StartingPlayerName:  "a",
+		DefendingPlayerName: defendingPlayerName,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return game
+}
+
+func getAttackingPlayerNames(game *Game) map[string]bool {
+	names := make(map[string]bool)
+	for _, player := range game.players {
+		if game.rules.CanPlayerAttackNow(game, player) {
+			names[player.Name] = true
+		}
+	}
+	return names
+}
+
+func checkAttackingPlayerNames(t *testing.T, game *Game, expected ...string) {
+	t.Helper()
+	names := getAttackingPlayerNames(game)
+	if len(names) != len(expected) {
+		t.Fatalf("expected %v to be able to attack, got %v", expected, names)
+	}
+	for _, name := range expected {
+		if !names[name] {
+			t.Fatalf("expected %v to be able to attack, got %v", expected, names)
+		}
+	}
+}
+
+func TestNeighboursOnlyRules(t *testing.T) {
+	tests := []struct {
+		name            string
+		players         []*PlayerState
+		defendingPlayer string
+		attackers       []string
+	}{
+		{
+			name: "three players",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: cardsByCode(t, "9H")},
+			},
+			defendingPlayer: "b",
+			attackers:       []string{"a", "c"},
+		},
+		{
+			name: "four players",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: cardsByCode(t, "9H")},
+				{Name: "d", Cards: cardsByCode(t, "10H")},
+			},
+			defendingPlayer: "b",
+			attackers:       []string{"a", "c"},
+		},
+		{
+			name: "four players, defender sitting last",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: cardsByCode(t, "9H")},
+				{Name: "d", Cards: cardsByCode(t, "10H")},
+			},
+			defendingPlayer: "d",
+			attackers:       []string{"a", "c"},
+		},
+		{
+			name: "four players, one is out",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", IsOut: true},
+				{Name: "d", Cards: cardsByCode(t, "10H")},
+			},
+			defendingPlayer: "b",
+			attackers:       []string{"a", "d"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := newNeighboursOnlyGame(t, test.players, test.defendingPlayer)
+			checkAttackingPlayerNames(t, game, test.attackers...)
+		})
+	}
+}
+
+func TestNeighboursOnlyRulesOpeningAttack(t *testing.T) {
+	game := newNeighboursOnlyGame(t, []*PlayerState{
+		{Name: "a", Cards: cardsByCode(t, "7H")},
+		{Name: "b", Cards: cardsByCode(t, "8H")},
+		{Name: "c", Cards: cardsByCode(t, "9H")},
+		{Name: "d", Cards: cardsByCode(t, "10H")},
+	}, "b")
+	game.board.EmptyBoard()
+
+	checkAttackingPlayerNames(t, game, "a")
+}
+
+func TestNeighboursOnlyRulesAfterPlayersFinished(t *testing.T) {
+	// Players with no cards left once deck is empty are taken out of the ring
+
+	tests := []struct {
+		name            string
+		players         []*PlayerState
+		defendingPlayer string
+		attackers       []string
+	}{
+		{
+			name: "three players, one finished",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: []*Card{}},
+			},
+			defendingPlayer: "b",
+			attackers:       []string{"a"},
+		},
+		{
+			name: "four players, one finished",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: []*Card{}},
+				{Name: "d", Cards: cardsByCode(t, "10H")},
+			},
+			defendingPlayer: "b",
+			attackers:       []string{"a", "d"},
+		},
+		{
+			name: "five players, two finished next to each other",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: []*Card{}},
+				{Name: "d", Cards: []*Card{}},
+				{Name: "e", Cards: cardsByCode(t, "10H")},
+			},
+			defendingPlayer: "b",
+			attackers:       []string{"a", "e"},
+		},
+		{
+			name: "five players, finished player was sitting before defender",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: cardsByCode(t, "9H")},
+				{Name: "d", Cards: []*Card{}},
+				{Name: "e", Cards: cardsByCode(t, "10H")},
+			},
+			defendingPlayer: "e",
+			attackers:       []string{"a", "c"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := newNeighboursOnlyGame(t, test.players, test.defendingPlayer)
+			game.removePlayersThatFinished()
+			checkAttackingPlayerNames(t, game, test.attackers...)
+		})
+	}
+}
+
+func TestNeighboursOnlyRulesAfterPlayerLeft(t *testing.T) {
+	tests := []struct {
+		name            string
+		players         []*PlayerState
+		defendingPlayer string
+		leavingPlayer   string
+		attackers       []string
+	}{
+		{
+			name: "four players, attacker left",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: cardsByCode(t, "9H")},
+				{Name: "d", Cards: cardsByCode(t, "10H")},
+			},
+			defendingPlayer: "b",
+			leavingPlayer:   "c",
+			attackers:       []string{"a", "d"},
+		},
+		{
+			name: "four players, player not sitting next to defender left",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", Cards: cardsByCode(t, "9H")},
+				{Name: "d", Cards: cardsByCode(t, "10H")},
+			},
+			defendingPlayer: "b",
+			leavingPlayer:   "d",
+			attackers:       []string{"a", "c"},
+		},
+		{
+			name: "five players, one is out and another left",
+			players: []*PlayerState{
+				{Name: "a", Cards: cardsByCode(t, "7H")},
+				{Name: "b", Cards: cardsByCode(t, "8H")},
+				{Name: "c", IsOut: true},
+				{Name: "d", Cards: cardsByCode(t, "10H")},
+				{Name: "e", Cards: cardsByCode(t, "JH")},
+			},
+			defendingPlayer: "b",
+			leavingPlayer:   "d",
+			attackers:       []string{"a", "e"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := newNeighboursOnlyGame(t, test.players, test.defendingPlayer)
+			if err := game.HandlePlayerLeft(test.leavingPlayer); err != nil {
+				t.Fatal(err)
+			}
+			if game.GetDefendingPlayer().Name != test.defendingPlayer {
+				t.Fatalf("defending player changed to %s", game.GetDefendingPlayer().Name)
+			}
+			checkAttackingPlayerNames(t, game, test.attackers...)
+		})
+	}
+}
+
+func TestNeighboursOnlyRulesAfterDefenderLeft(t *testing.T) {
+	// Cards go back to their owners and next player defends a new attack
+	game := newNeighboursOnlyGame(t, []*PlayerState{
+		{Name: "a", Cards: cardsByCode(t, "7H")},
+		{Name: "b", Cards: cardsByCode(t, "8H")},
+		{Name: "c", Cards: cardsByCode(t, "9H")},
+		{Name: "d", Cards: cardsByCode(t, "10H")},
+	}, "b")
+	if err := game.HandlePlayerLeft("b"); err != nil {
+		t.Fatal(err)
+	}
+
+	a, _ := game.GetPlayerByName("a")
+	if game.GetDefendingPlayer().Name != "c" || !game.board.IsEmpty() {
+		t.Fatalf("expected c to defend a new attack, got %s defending with board %v", game.GetDefendingPlayer().Name, game.board)
+	}
+	if err := game.Attack(a, cardsByCode(t, "7H")...); err != nil {
+		t.Fatal(err)
+	}
+	checkAttackingPlayerNames(t, game, "a", "d")
+}
@@ -0,0 +1,101 @@
+package game
+
+import (
+	"DurakGo/output"
+	"fmt"
+)
+
+// Shuler durak - illegal attacks and defences are accepted but recorded.
+// Until the bout is over, any other player may call cheat. A cheater that is caught takes all cards on board,
+// a player calling cheat for nothing draws a penalty card from the deck, or takes all cards on board when
+// no cards are left in deck.
+
+const ShulerPenaltyCards = 1
+
+type Violation struct {
+	Player *Player
+	Cards  []*Card
+	Reason string
+}
+
+func (this *Game) Challenge(challenger *Player) (*Player, error) {
+	// Returns the player caught cheating, or nil if challenge was false
+
+	if !this.options.IsShuler {
+		return nil, newGameError(ErrNotAllowed, "challenges are allowed only in shuler games")
+	}
+
+	if err := this.validateGameNotOver(); err != nil {
+		return nil, err
+	}
+
+	if !challenger.IsPlaying {
+		return nil, newGameError(ErrNotPlaying, "%s is not playing", challenger.Name)
+	}
+
+	if this.board.IsEmpty() {
+		return nil, newGameError(ErrWrongPhase, "board is empty, nothing to challenge")
+	}
+
+	this.recordEvent(EventChallenge, challenger.Name)
+
+	violation := this.getLastViolationOfOthers(challenger)
+	if violation == nil {
+		output.Spit(fmt.Sprintf("%s called cheat for nothing", challenger.Name))
+		this.penaliseFalseChallenge(challenger)
+		return nil, nil
+	}
+
+	output.Spit(fmt.Sprintf("%s caught %s cheating: %s", challenger.Name, violation.Player.Name, violation.Reason))
+	this.penaliseCheater(violation.Player)
+	return violation.Player, nil
+}
+
+func (this *Game) recordViolation(player *Player, cards []*Card, reason error) {
+	output.Spit(fmt.Sprintf("%s broke the rules (%s)", player.Name, reason))
+
+	this.violations = append(this.violations, &Violation{
+		Player: player,
+		Cards:  cards,
+		Reason: reason.Error(),
+	})
+}
+
+func (this *Game) getLastViolationOfOthers(challenger *Player) *Violation {
+	for i := len(this.violations) - 1; i >= 0; i-- {
+		violation := this.violations[i]
+		if violation.Player != challenger && violation.Player.IsPlaying {
+			return violation
+		}
+	}
+	return nil
+}
+
+func (this *Game) penaliseCheater(cheater *Player) {
+	this.takeBoardAsPenalty(cheater)
+}
+
+func (this *Game) penaliseFalseChallenge(challenger *Player) {
+	// Without a stock the penalty can not be drawn, so challenger is penalised like a caught cheater
+	if this.deck.GetNumOfCardsLeft() == 0 {
+		output.Spit(fmt.Sprintf("No cards left in deck, %s takes the board", challenger.Name))
+		this.takeBoardAsPenalty(challenger)
+		return
+	}
+
+	for i := 0; i < ShulerPenaltyCards && this.deck.GetNumOfCardsLeft() > 0; i++ {
+		challenger.TakeCards(this.deck.GetNextCard())
+	}
+	this.updatePhase()
+}
+
+func (this *Game) takeBoardAsPenalty(player *Player) {
+	// Player takes the board and the bout is over. If the player attacked, the defender is
+	// considered as defending successfully
+
+	cards := this.board.PeekCards()
+	player.TakeCards(cards...)
+	this.board.EmptyBoard()
+	this.fillUpCards()
+	this.finalizeTurn(player != this.defendingPlayer)
+}
@@ -0,0 +1,83 @@
+package game
+
+import "testing"
+
+func newShulerGame(t *testing.T, deck []*Card) *Game {
+	// Attack of a on b is defended illegally with a lower card of another kind
+	t.Helper()
+	options := NewDefaultGameOptions()
+	options.IsShuler = true
+	game, err := NewGameFromState(&GameState{
+		Options: options,
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "9H", "10H")},
+			{Name: "b", Cards: cardsByCode(t, "6C", "JH")},
+			{Name: "c", Cards: cardsByCode(t, "QH", "KH")},
+		},
+		Deck:                deck,
+		KozerCard:           cardsByCode(t, "AS")[0],
+		Board:               []*CardOnBoardState{{AttackingCard: cardsByCode(t, "8D")[0], AttackerName: "a"}},
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := game.GetPlayerByName("b")
+	if err := game.Defend(b, &Defence{AttackingCard: cardsByCode(t, "8D")[0], DefendingCard: cardsByCode(t, "6C")[0]}); err != nil {
+		t.Fatal(err)
+	}
+	return game
+}
+
+func TestChallengeCatchesCheater(t *testing.T) {
+	game := newShulerGame(t, cardsByCode(t, "7D", "AS"))
+	c, _ := game.GetPlayerByName("c")
+
+	cheater, err := game.Challenge(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cheater == nil || cheater.Name != "b" {
+		t.Fatalf("expected b to be caught cheating, got %v", cheater)
+	}
+	if !game.board.IsEmpty() || cheater.GetNumOfCardsInHand() != 3 {
+		t.Fatalf("cheater must take the board, board: %v, cheater: %v", game.board, cheater)
+	}
+}
+
+func TestFalseChallengePenalty(t *testing.T) {
+	tests := []struct {
+		name             string
+		deck             []*Card
+		numOfCardsInHand int
+		isBoardTaken     bool
+	}{
+		{name: "penalty card drawn from deck", deck: cardsByCode(t, "7D", "AS"), numOfCardsInHand: 2},
+		{name: "no cards left in deck", deck: []*Card{}, numOfCardsInHand: 3, isBoardTaken: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := newShulerGame(t, test.deck)
+			b, _ := game.GetPlayerByName("b")
+
+			// Only b broke the rules, b can not catch anyone
+			cheater, err := game.Challenge(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cheater != nil {
+				t.Fatalf("challenge must be false, %s was caught", cheater.Name)
+			}
+
+			if b.GetNumOfCardsInHand() != test.numOfCardsInHand {
+				t.Fatalf("expected challenger to hold %d cards, got %v", test.numOfCardsInHand, b)
+			}
+			if game.board.IsEmpty() != test.isBoardTaken {
+				t.Fatalf("unexpected board after false challenge: %v", game.board)
+			}
+		})
+	}
+}
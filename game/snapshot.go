@@ -0,0 +1,114 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Whole game serialization, so a running game can survive a process restart.
+// The position itself is a GameState, the snapshot adds what is needed for the game to go on identically.
+
+const SnapshotVersion = 1
+
+type gameSnapshot struct {
+	Version           int                  `json:"version"`
+	State             *GameState           `json:"state"`
+	Seed              int64                `json:"seed"`
+	NumOfRandomDraws  uint64               `json:"numOfRandomDraws"`
+	LosingHand        []*Card              `json:"losingHand"`
+	LosingHands       map[string][]*Card   `json:"losingHands"`
+	IsAbandoned       bool                 `json:"isAbandoned"`
+	RedealPlayerNames []string             `json:"redealPlayers"`
+	IsKozerSwapOpen   bool                 `json:"isKozerSwapOpen"`
+	Violations        []*violationSnapshot `json:"violations"`
+	DeckOrder         []string             `json:"deckOrder"`
+	DeckSalt          string               `json:"deckSalt"`
+	DeckCommitment    string               `json:"deckCommitment"`
+	Events            []*Event             `json:"events"`
+}
+
+type violationSnapshot struct {
+	PlayerName string  `json:"playerName"`
+	Cards      []*Card `json:"cards"`
+	Reason     string  `json:"reason"`
+}
+
+func (this *Game) MarshalJSON() ([]byte, error) {  // JSON Serialization override
+	if this.options.Rules != nil {
+		return nil, errors.New("games with a custom rule set can not be serialized")
+	}
+
+	snapshot := gameSnapshot{
+		Version:           SnapshotVersion,
+		State:             this.GetState(),
+		Seed:              this.seed,
+		NumOfRandomDraws:  this.randomSource.numOfDraws,
+		LosingHand:        this.losingHand,
+		LosingHands:       this.losingHands,
+		IsAbandoned:       this.isAbandoned,
+		RedealPlayerNames: this.GetRedealPlayerNames(),
+		IsKozerSwapOpen:   this.isKozerSwapOpen,
+		Violations:        make([]*violationSnapshot, 0, len(this.violations)),
+		DeckOrder:         this.deckOrder,
+		DeckSalt:          this.deckSalt,
+		DeckCommitment:    this.deckCommitment,
+		Events:            this.events,
+	}
+
+	// Violations of players that are out can not be challenged anymore
+	for _, violation := range this.violations {
+		if !violation.Player.IsPlaying {
+			continue
+		}
+		snapshot.Violations = append(snapshot.Violations, &violationSnapshot{
+			PlayerName: violation.Player.Name,
+			Cards:      violation.Cards,
+			Reason:     violation.Reason,
+		})
+	}
+
+	return json.Marshal(snapshot)
+}
+
+func (this *Game) UnmarshalJSON(data []byte) error {
+	snapshot := gameSnapshot{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return fmt.Errorf("could not unmarshal game snapshot: %s", err)
+	}
+
+	if snapshot.Version != SnapshotVersion {
+		return fmt.Errorf("unsupported game snapshot version: %d", snapshot.Version)
+	}
+
+	game, err := NewGameFromState(snapshot.State)
+	if err != nil {
+		return err
+	}
+
+	game.seed = snapshot.Seed
+	game.rng, game.randomSource = newGameRandom(snapshot.Seed, snapshot.NumOfRandomDraws)
+	game.losingHand = snapshot.LosingHand
+	game.losingHands = snapshot.LosingHands
+	game.isAbandoned = snapshot.IsAbandoned
+	game.deckOrder = snapshot.DeckOrder
+	game.deckSalt = snapshot.DeckSalt
+	game.deckCommitment = snapshot.DeckCommitment
+	game.events = snapshot.Events
+	game.isKozerSwapOpen = snapshot.IsKozerSwapOpen
+
+	for _, name := range snapshot.RedealPlayerNames {
+		player, err := game.GetPlayerByName(name)
+		if err != nil { return err}
+		game.redealPlayers[player] = true
+	}
+
+	for _, violation := range snapshot.Violations {
+		player, err := game.GetPlayerByName(violation.PlayerName)
+		if err != nil { return err}
+		game.violations = append(game.violations, &Violation{Player: player, Cards: violation.Cards, Reason: violation.Reason})
+	}
+
+	*this = *game
+	return nil
+}
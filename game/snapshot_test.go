@@ -0,0 +1,141 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func restoreSnapshot(t *testing.T, game *Game) *Game {
+	t.Helper()
+	data, err := json.Marshal(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restoredGame := &Game{}
+	if err := json.Unmarshal(data, restoredGame); err != nil {
+		t.Fatal(err)
+	}
+
+	restoredData, err := json.Marshal(restoredGame)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(restoredData) {
+		t.Fatalf("restored snapshot differs:\n%s\n%s", data, restoredData)
+	}
+	return restoredGame
+}
+
+func checkSameGame(t *testing.T, game *Game, restoredGame *Game) {
+	t.Helper()
+	if game.GetPhase() != restoredGame.GetPhase() {
+		t.Fatalf("phase %s restored as %s", game.GetPhase(), restoredGame.GetPhase())
+	}
+	if game.IsGameOver() != restoredGame.IsGameOver() || game.GetLosingPlayerName() != restoredGame.GetLosingPlayerName() {
+		t.Fatalf("game over %v (%s) restored as %v (%s)", game.IsGameOver(), game.GetLosingPlayerName(),
+			restoredGame.IsGameOver(), restoredGame.GetLosingPlayerName())
+	}
+
+	names := game.GetPlayerNamesArray()
+	restoredNames := restoredGame.GetPlayerNamesArray()
+	if len(names) != len(restoredNames) {
+		t.Fatalf("players %v restored as %v", names, restoredNames)
+	}
+	if game.GetStartingPlayer().Name != restoredGame.GetStartingPlayer().Name ||
+		game.GetDefendingPlayer().Name != restoredGame.GetDefendingPlayer().Name {
+		t.Fatalf("%s attacking %s restored as %s attacking %s", game.GetStartingPlayer().Name, game.GetDefendingPlayer().Name,
+			restoredGame.GetStartingPlayer().Name, restoredGame.GetDefendingPlayer().Name)
+	}
+
+	// Players on turn may have left once game is over
+	if restoredGame.IsGameOver() {
+		return
+	}
+	for _, player := range []*Player{restoredGame.GetStartingPlayer(), restoredGame.GetDefendingPlayer()} {
+		if _, err := restoredGame.GetPlayerByName(player.Name); err != nil {
+			t.Fatalf("%s is not seated in restored game", player.Name)
+		}
+	}
+}
+
+func TestSnapshotAfterPlayerLeft(t *testing.T) {
+	tests := []struct {
+		name          string
+		leavingPlayer string
+		isGameOver    bool
+	}{
+		{name: "attacker left mid bout", leavingPlayer: "a", isGameOver: true},
+		{name: "defender left mid bout", leavingPlayer: "b", isGameOver: true},
+		{name: "third player left", leavingPlayer: "c"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game, err := NewGameFromState(&GameState{
+				Players: []*PlayerState{
+					{Name: "a", Cards: cardsByCode(t, "9H", "10H")},
+					{Name: "b", Cards: cardsByCode(t, "JH", "QH")},
+					{Name: "c", Cards: cardsByCode(t, "KH", "6H")},
+				},
+				KozerCard: cardsByCode(t, "AS")[0],
+				Board: []*CardOnBoardState{
+					{AttackingCard: cardsByCode(t, "7D")[0], AttackerName: "a", DefendingCard: cardsByCode(t, "8D")[0], DefenderName: "b"},
+					{AttackingCard: cardsByCode(t, "7C")[0], AttackerName: "c"},
+				},
+				StartingPlayerName:  "a",
+				DefendingPlayerName: "b",
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Game is over once only two players are left and one of them leaves
+			if test.isGameOver {
+				if err := game.HandlePlayerLeft("c"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := game.HandlePlayerLeft(test.leavingPlayer); err != nil {
+				t.Fatal(err)
+			}
+
+			if game.IsGameOver() != test.isGameOver {
+				t.Fatalf("expected game over to be %v", test.isGameOver)
+			}
+			if test.isGameOver && !game.board.IsEmpty() {
+				t.Fatalf("board must be cleared once game is over, got %v", game.board)
+			}
+
+			checkSameGame(t, game, restoreSnapshot(t, game))
+		})
+	}
+}
+
+func TestSnapshotAfterLosingPlayerLeftFinishedGame(t *testing.T) {
+	game, err := NewGameFromState(&GameState{
+		Players: []*PlayerState{
+			{Name: "a", IsOut: true},
+			{Name: "b", Cards: cardsByCode(t, "6H", "6D")},
+		},
+		KozerCard:           cardsByCode(t, "AS")[0],
+		StartingPlayerName:  "b",
+		DefendingPlayerName: "a",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.recordLosingHand()
+
+	if err := game.HandlePlayerLeft("b"); err != nil {
+		t.Fatal(err)
+	}
+	if !game.IsPogony() || len(game.GetLosingPlayerFinalHand()) != 2 {
+		t.Fatalf("losing hand must be kept after losing player left, got %v", game.GetLosingPlayerFinalHand())
+	}
+
+	restoredGame := restoreSnapshot(t, game)
+	checkSameGame(t, game, restoredGame)
+	if !restoredGame.IsPogony() {
+		t.Fatalf("losing hand must be restored")
+	}
+}
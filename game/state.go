@@ -0,0 +1,328 @@
+package game
+
+import (
+	"DurakGo/output"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Explicit game position, used to build a game without dealing randomly
+// (rule tests, puzzle positions, reproducing a reported bug)
+
+type GameState struct {
+	Options             *GameOptions        `json:"options"`  // Default options if nil
+	Players             []*PlayerState      `json:"players"`  // In seating order
+	NumOfPlayersAtStart int                 `json:"numOfPlayersAtStart"`  // Players may have left since, 0 for number of players
+	Deck                []*Card             `json:"deck"`  // In dealing order, kozer card last
+	KozerCard           *Card               `json:"kozerCard"`
+	Board               []*CardOnBoardState `json:"board"`
+	StartingPlayerName  string              `json:"startingPlayer"`
+	DefendingPlayerName string              `json:"defendingPlayer"`
+	TransferringPlayerName string           `json:"transferringPlayer"`  // Empty if attack was not transferred
+	IsTakeDeclared      bool                `json:"isTakeDeclared"`
+	PassedPlayerNames   []string            `json:"passedPlayers"`
+}
+
+type PlayerState struct {
+	Name  string  `json:"name"`
+	Cards []*Card `json:"cards"`
+	IsOut bool    `json:"isOut"`  // Finished the game
+	Team  int     `json:"team"`  // Team games only, 0 to seat partners opposite each other
+}
+
+type CardOnBoardState struct {
+	AttackingCard *Card  `json:"attackingCard"`
+	AttackerName  string `json:"attacker"`
+	DefendingCard *Card  `json:"defendingCard"`  // nil if not defended yet
+	DefenderName  string `json:"defender"`
+}
+
+func NewGameFromState(state *GameState) (*Game, error) {
+	if state == nil {
+		return nil, errors.New("game state is missing")
+	}
+
+	options := state.Options
+	if options == nil {
+		options = NewDefaultGameOptions()
+	}
+
+	numOfPlayers := state.NumOfPlayersAtStart
+	if numOfPlayers == 0 {
+		numOfPlayers = len(state.Players)
+	}
+	if numOfPlayers < 2 || numOfPlayers < len(state.Players) {
+		return nil, fmt.Errorf("%d players can not have started a game of %d players", numOfPlayers, len(state.Players))
+	}
+
+	if err := options.Validate(numOfPlayers); err != nil {
+		return nil, err
+	}
+
+	rules, err := options.GetRuleSet()
+	if err != nil { return nil, err}
+
+	if err := state.validateCards(options); err != nil {
+		return nil, err
+	}
+
+	seed := options.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	rng, randomSource := newGameRandom(seed, 0)
+	game := Game{board: NewBoard(), deck: &Deck{cards: append([]*Card{}, state.Deck...)}, options: options,
+		rules: rules, seed: seed, rng: rng, randomSource: randomSource, numOfPlayersAtStart: numOfPlayers}
+
+	if err := game.setUpPlayersFromState(state.Players); err != nil {
+		return nil, err
+	}
+
+	// Kozer card is the same card as the last one in deck, when it is still there
+	game.KozerCard = state.KozerCard
+	if lastCardInDeck := game.deck.PeekLastCard(); lastCardInDeck != nil {
+		game.KozerCard = lastCardInDeck
+	}
+
+	// Owners of cards on board may have left the game, like the last players of a finished game.
+	// As in a running game, they are not seated anymore
+	leftPlayers := make(map[string]*Player)
+	getPlayerOrLeftPlayer := func(name string) (*Player, error) {
+		if name == "" {
+			return nil, errors.New("player name is missing")
+		}
+		if player, err := game.GetPlayerByName(name); err == nil {
+			return player, nil
+		}
+		if leftPlayers[name] == nil {
+			leftPlayers[name] = NewPlayer(name)
+			leftPlayers[name].IsPlaying = false
+		}
+		return leftPlayers[name], nil
+	}
+
+	getPlayerByName := game.getActivePlayerByName
+	if game.IsGameOver() {
+		getPlayerByName = getPlayerOrLeftPlayer
+	}
+
+	if game.startingPlayer, err = getPlayerByName(state.StartingPlayerName); err != nil {
+		return nil, err
+	}
+	if game.defendingPlayer, err = getPlayerByName(state.DefendingPlayerName); err != nil {
+		return nil, err
+	}
+	// Attack may be transferred back to the player that opened it
+	if game.startingPlayer == game.defendingPlayer && !game.IsGameOver() && len(state.Board) == 0 {
+		return nil, errors.New("starting player can not defend")
+	}
+
+	if err := game.setUpBoardFromState(state.Board, getPlayerOrLeftPlayer); err != nil {
+		return nil, err
+	}
+	if !game.IsGameOver() && len(game.board.peekUndefendedCards()) > game.defendingPlayer.GetNumOfCardsInHand() {
+		return nil, fmt.Errorf("%s does not have enough cards to defend", game.defendingPlayer.Name)
+	}
+
+	if state.TransferringPlayerName != "" && !game.board.IsEmpty() {
+		if game.transferringPlayer, err = game.GetPlayerByName(state.TransferringPlayerName); err != nil {
+			return nil, err
+		}
+	}
+
+	game.isTakeDeclared = state.IsTakeDeclared && !game.board.IsEmpty()
+	game.redealPlayers = make(map[*Player]bool)
+	game.resetPasses()
+	for _, name := range state.PassedPlayerNames {
+		player, err := game.GetPlayerByName(name)
+		if err != nil { return nil, err}
+		game.passedPlayers[player] = true
+	}
+	game.updatePhase()
+
+	if err := game.commitToDeck(); err != nil { return nil, err}
+
+	// Loaded position is the deal, so the game can be replayed like a dealt one
+	dealOptions := *options
+	dealOptions.Seed = seed
+	dealEvent := game.recordEvent(EventDeal, "")
+	dealEvent.Options = &dealOptions
+	dealEvent.PlayerNames = game.GetPlayerNamesArray()
+	dealEvent.State = game.GetState()
+	dealEvent.State.Options = nil  // Same as deal options
+
+	output.Spit(fmt.Sprintf("Game loaded from state, seed: %d", seed))
+	return &game, nil
+}
+
+func (this *Game) GetState() *GameState {
+	// Current position of the game, NewGameFromState builds the same position back
+
+	state := &GameState{
+		Options:             this.options,
+		Players:             make([]*PlayerState, 0, len(this.players)),
+		NumOfPlayersAtStart: this.numOfPlayersAtStart,
+		Deck:                append([]*Card{}, this.deck.cards...),
+		KozerCard:           this.KozerCard,
+		Board:               make([]*CardOnBoardState, 0),
+		StartingPlayerName:  this.startingPlayer.Name,
+		DefendingPlayerName: this.defendingPlayer.Name,
+		TransferringPlayerName: this.GetTransferringPlayerName(),
+		IsTakeDeclared:      this.isTakeDeclared,
+		PassedPlayerNames:   this.GetPassedPlayerNames(),
+	}
+
+	for _, player := range this.players {
+		state.Players = append(state.Players, &PlayerState{
+			Name:  player.Name,
+			Cards: player.PeekCards(),
+			IsOut: !player.IsPlaying,
+			Team:  player.Team,
+		})
+	}
+
+	for _, cardOnBoard := range this.board.PeekCardsOnBoard() {
+		cardOnBoardState := &CardOnBoardState{
+			AttackingCard: cardOnBoard.attackingCard,
+			AttackerName:  cardOnBoard.attackingCardOwner.Name,
+			DefendingCard: cardOnBoard.defendingCard,
+		}
+		if cardOnBoard.defendingCardOwner != nil {
+			cardOnBoardState.DefenderName = cardOnBoard.defendingCardOwner.Name
+		}
+		state.Board = append(state.Board, cardOnBoardState)
+	}
+
+	return state
+}
+
+func (this *GameState) validateCards(options *GameOptions) error {
+	// Every card must come from the decks of the game and be in one place only
+	if this.KozerCard == nil || this.KozerCard.IsJoker() {
+		return newGameError(ErrInvalidCard, "kozer card is not valid")
+	}
+
+	cards := append([]*Card{}, this.Deck...)
+	for _, player := range this.Players {
+		if player == nil {
+			return errors.New("player is not valid (most likely nil)")
+		}
+		if player.IsOut && len(player.Cards) > 0 {
+			return fmt.Errorf("%s is out but still holds cards", player.Name)
+		}
+		cards = append(cards, player.Cards...)
+	}
+	for _, cardOnBoard := range this.Board {
+		if cardOnBoard == nil {
+			return errors.New("card on board is not valid (most likely nil)")
+		}
+		cards = append(cards, cardOnBoard.AttackingCard)
+		if cardOnBoard.DefendingCard != nil {
+			cards = append(cards, cardOnBoard.DefendingCard)
+		}
+	}
+
+	minCardValue, err := options.GetMinCardValue()
+	if err != nil { return err}
+
+	for i, card := range append(cards, this.KozerCard) {
+		if card == nil {
+			return newGameError(ErrInvalidCard, "card is not valid (most likely nil)")
+		}
+		if _, err := NewCard(card.Kind, card.Value); err != nil {
+			return newGameError(ErrInvalidCard, "card is not valid: %s", err)
+		}
+		if card.DeckIndex >= uint(options.NumOfDecks) || (card.IsJoker() && !options.IsWithJokers) ||
+			(!card.IsJoker() && card.Value < minCardValue) {
+			return newGameError(ErrInvalidCard, "%s is not in the decks of this game", card)
+		}
+		if i == len(cards) {
+			break  // Kozer card is also the last card in deck or in some other place
+		}
+
+		for _, otherCard := range cards[:i] {
+			if card.IsSameCard(otherCard) {
+				return fmt.Errorf("%s appears more than once", card)
+			}
+		}
+	}
+
+	if len(this.Deck) > 0 && !this.Deck[len(this.Deck)-1].IsSameCard(this.KozerCard) {
+		return newGameError(ErrInvalidCard, "kozer card %s must be the last card in deck", this.KozerCard)
+	}
+	return nil
+}
+
+func (this *Game) setUpPlayersFromState(playerStates []*PlayerState) error {
+	// Seats all players, players that are out point to the next active player like in a running game
+
+	for i, playerState := range playerStates {
+		if _, err := this.GetPlayerByName(playerState.Name); err == nil {
+			return fmt.Errorf("%s appears more than once", playerState.Name)
+		}
+
+		player := NewPlayer(playerState.Name)
+		player.TakeCards(playerState.Cards...)
+		player.IsPlaying = !playerState.IsOut
+		if this.options.IsTeamGame {
+			player.Team = playerState.Team
+			if player.Team == 0 {
+				player.Team = i%2 + 1
+			}
+		}
+
+		this.players = append(this.players, player)
+		if player.IsPlaying {
+			this.numOfActivePlayers++
+		}
+	}
+
+	for i, player := range this.players {
+		player.NextPlayer = this.players[(i+1)%len(this.players)]
+		for j := 1; j <= len(this.players); j++ {
+			nextPlayer := this.players[(i+j)%len(this.players)]
+			if nextPlayer.IsPlaying {
+				player.NextPlayer = nextPlayer
+				break
+			}
+		}
+	}
+	return nil
+}
+
+func (this *Game) setUpBoardFromState(cardsOnBoard []*CardOnBoardState, getOwner func(string) (*Player, error)) error {
+	if len(cardsOnBoard) > this.options.MaxCardsPerAttack {
+		return errors.New("attacking cards limit reached")
+	}
+
+	for _, cardOnBoard := range cardsOnBoard {
+		attacker, err := getOwner(cardOnBoard.AttackerName)
+		if err != nil { return err}
+		this.board.AddAttackingCard(cardOnBoard.AttackingCard, attacker)
+
+		if cardOnBoard.DefendingCard != nil {
+			defender, err := getOwner(cardOnBoard.DefenderName)
+			if err != nil { return err}
+			if defender != this.defendingPlayer && !this.IsGameOver() {
+				return fmt.Errorf("%s is defended by %s, who is not defending", cardOnBoard.AttackingCard, defender.Name)
+			}
+			if err := this.board.AddDefendingCard(cardOnBoard.AttackingCard, cardOnBoard.DefendingCard, defender); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (this *Game) getActivePlayerByName(name string) (*Player, error) {
+	player, err := this.GetPlayerByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if !player.IsPlaying {
+		return nil, fmt.Errorf("%s is not playing", name)
+	}
+	return player, nil
+}
@@ -0,0 +1,88 @@
+package game
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newValidState(t *testing.T) *GameState {
+	// a attacked b with 7C, b beat it with 8C
+	return &GameState{
+		Players: []*PlayerState{
+			{Name: "a", Cards: cardsByCode(t, "9D", "10D")},
+			{Name: "b", Cards: cardsByCode(t, "JD", "QD")},
+		},
+		Deck:      cardsByCode(t, "6D", "AS"),
+		KozerCard: cardsByCode(t, "AS")[0],
+		Board: []*CardOnBoardState{
+			{AttackingCard: cardsByCode(t, "7C")[0], AttackerName: "a", DefendingCard: cardsByCode(t, "8C")[0], DefenderName: "b"},
+		},
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	}
+}
+
+func TestNewGameFromInvalidState(t *testing.T) {
+	if _, err := NewGameFromState(newValidState(t)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		change func(state *GameState)
+		err    error
+	}{
+		{name: "missing card", change: func(state *GameState) { state.Players[0].Cards[0] = nil }, err: ErrInvalidCard},
+		{name: "bad card", change: func(state *GameState) { state.Players[0].Cards[0] = &Card{Kind: Clubs, Value: 15} }, err: ErrInvalidCard},
+		{name: "bad kind", change: func(state *GameState) { state.Deck[0] = &Card{Kind: Kind("Stars"), Value: 7} }, err: ErrInvalidCard},
+		{name: "card below deck", change: func(state *GameState) { state.Players[1].Cards[0] = &Card{Kind: Clubs, Value: 2} }, err: ErrInvalidCard},
+		{name: "joker without jokers", change: func(state *GameState) { state.Players[1].Cards[0] = cardsByCode(t, "XR")[0] }, err: ErrInvalidCard},
+		{name: "second deck card", change: func(state *GameState) { state.Players[1].Cards[0] = cardsByCode(t, "JD#1")[0] }, err: ErrInvalidCard},
+		{name: "kozer not last in deck", change: func(state *GameState) { state.KozerCard = cardsByCode(t, "KS")[0] }, err: ErrInvalidCard},
+		{name: "duplicate card", change: func(state *GameState) { state.Players[1].Cards[0] = cardsByCode(t, "7C")[0] }},
+		{name: "out player with cards", change: func(state *GameState) { state.Players = append(state.Players, &PlayerState{Name: "c", Cards: cardsByCode(t, "KC"), IsOut: true}) }},
+		{name: "single player", change: func(state *GameState) { state.Players = state.Players[:1] }},
+		{name: "defended by attacker", change: func(state *GameState) { state.Board[0].DefenderName = "a" }},
+		{name: "too many undefended cards", change: func(state *GameState) {
+			state.Board = append(state.Board, &CardOnBoardState{AttackingCard: cardsByCode(t, "7H")[0], AttackerName: "a"},
+				&CardOnBoardState{AttackingCard: cardsByCode(t, "8H")[0], AttackerName: "a"},
+				&CardOnBoardState{AttackingCard: cardsByCode(t, "8S")[0], AttackerName: "a"})
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := newValidState(t)
+			test.change(state)
+			game, err := NewGameFromState(state)
+			if err == nil {
+				t.Fatalf("state was loaded, board %v", game.board)
+			}
+			if test.err != nil && !errors.Is(err, test.err) {
+				t.Fatalf("expected %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestUnmarshalInvalidSnapshot(t *testing.T) {
+	game, err := NewGameFromState(newValidState(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Malformed card codes and positions are errors, never a crash
+	for _, change := range [][2]string{{`"9D"`, `"XC"`}, {`"9D"`, `""`}, {`"9D"`, `"C"`}, {`"9D"`, `"8C"`}, {`"AS"`, `"KS"`}} {
+		changedData := strings.Replace(string(data), change[0], change[1], 1)
+		restoredGame := &Game{}
+		if err := json.Unmarshal([]byte(changedData), restoredGame); err == nil {
+			t.Fatalf("snapshot with %s instead of %s was restored", change[1], change[0])
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"DurakGo/game"
 	"DurakGo/output"
 	"DurakGo/server/httpPayloadTypes"
+	"DurakGo/server/stream"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -91,7 +92,7 @@ func createGame(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse request
-	requestData := httpPayloadTypes.CreateGameRequestObject{}
+	requestData := httpPayloadTypes.CreateGameRequestObject{Options: game.NewDefaultGameOptions()}
 	if err := extractJSONData(&requestData, r); err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
@@ -102,20 +103,25 @@ func createGame(w http.ResponseWriter, r *http.Request) {
 
 	if err := validatePlayerName(playerName); err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
-		unCreateGame()
 		return
 	}
 
 	// Create game
 
 	if err := validateCreateGame(requestData); err != nil {
-		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
+		handleGameError(w, err)
+		return
+	}
+
+	// Players must not know the deck order, seeds are for reproducing games while debugging
+	if requestData.Options.Seed != 0 && !configuration.GetBool("IsGameSeedAllowed") {
+		http.Error(w, createErrorJson("game seed can only be set in debug environments"), http.StatusForbidden)
 		return
 	}
 
-	newGame := gameManager.CreateNewGame(numOfPlayers)
-	if newGame == nil {
-		http.Error(w, createErrorJson("game has already been created"), http.StatusBadRequest)
+	gameHolder := gameManager.CreateNewGame(numOfPlayers, requestData.Options)
+	if gameHolder == nil {
+		handleGameError(w, ErrGameAlreadyCreated)
 		return
 	}
 
@@ -123,24 +129,27 @@ func createGame(w http.ResponseWriter, r *http.Request) {
 
 	// Join game
 
-	user.name = playerName
+	err = gameHolder.Execute(func() error {
+		if err := validateJoinGame(gameHolder, playerName); err != nil {
+			return err
+		}
 
-	if err := validateJoinGame(); err != nil {
-		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
-		unCreateGame()
-		return
-	}
+		user.name = playerName
+		if err := handlePlayerJoin(gameHolder, user); err != nil {
+			return err
+		}
 
-	if err := handlePlayerJoin(user); err != nil {
-		http.Error(w, createErrorJson(err.Error()), 500)
+		if gameHolder.isGameStarted {
+			gameHolder.gameStreamer.Publish(getStartGameResponse(gameHolder))
+		}
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
 		unCreateGame()
 		return
 	}
 
-	if gameManager.IsGameStarted() {
-		gameStreamer.Publish(getStartGameResponse())
-	}
-
 	// Handle response
 
 	if err := integrateJSONResponse(getPlayerJoinedResponse(), &w); err != nil {
@@ -164,7 +173,7 @@ func joinGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := getUserByConnectionId(connectionId)
+	user := userManager.GetUserByConnectionId(connectionId)
 	if user == nil {
 		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
@@ -185,25 +194,28 @@ func joinGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validations
-
-	if err := validateJoinGame(); err != nil {
-		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
-		return
-	}
+	// Last player to join starts the game
+	err = runGameCommand(func(gameHolder *GameHolder) error {
+		if err := validateJoinGame(gameHolder, playerName); err != nil {
+			return err
+		}
 
-	user.name = playerName
+		user.name = playerName
+		if err := handlePlayerJoin(gameHolder, user); err != nil {
+			return err
+		}
 
-	if err := handlePlayerJoin(user); err != nil {
-		http.Error(w, createErrorJson(err.Error()), 500)
+		appStreamer.Publish(getGameStatusResponse())
+		if gameHolder.isGameStarted {
+			gameHolder.gameStreamer.Publish(getStartGameResponse(gameHolder))
+		}
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
 		return
 	}
 
-	appStreamer.Publish(getGameStatusResponse())
-	if isGameStarted {
-		gameStreamer.Publish(getStartGameResponse())
-	}
-
 	// Handle response
 	if err := integrateJSONResponse(getPlayerJoinedResponse(), &w); err != nil {
 		http.Error(w, createErrorJson(err.Error()), 500)
@@ -228,33 +240,34 @@ func registerToGameStream(w http.ResponseWriter, r *http.Request) {
 	}
 	connectionId := keys[0]
 
-	if !isGameCreated {
-		http.Error(w, createErrorJson("Game has not been created yet"), http.StatusBadRequest)
-		return
-	}
-
-	if !isGameStarted {
-		http.Error(w, createErrorJson("Game has not started yet"), http.StatusBadRequest)
-		return
-	}
-
-	user := getUserByConnectionId(connectionId)
+	user := userManager.GetUserByConnectionId(connectionId)
 	if user == nil {
 		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
 	}
 
-	// Open stream and create connection to player
+	// Open stream and create connection to player, current game is sent to everyone once registered
 
-	output.Spit(fmt.Sprintf("user ID %s registered to game stream", user))
+	var gameStreamer *stream.GameStreamer
+	var outgoingChannel chan httpPayloadTypes.JSONResponseData
+	err := runGameCommand(func(gameHolder *GameHolder) error {
+		if !gameHolder.isGameStarted {
+			return ErrGameNotStarted
+		}
 
-	outgoingChannel := gameStreamer.RegisterClient(&w)
-	user.gameChan = outgoingChannel
+		gameStreamer = gameHolder.gameStreamer
+		outgoingChannel = gameStreamer.RegisterClient(&w)
+		user.gameChan = outgoingChannel
 
-	if isGameStarted {
-		gameStreamer.Publish(getStartGameResponse())
+		gameStreamer.Publish(getStartGameResponse(gameHolder))
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
+		return
 	}
 
+	output.Spit(fmt.Sprintf("user ID %s registered to game stream", user))
 
 	gameStreamer.StreamLoop(&w, outgoingChannel, r, customizeDataPerPlayer(user.name))
 }
@@ -277,32 +290,33 @@ func leaveGame(w http.ResponseWriter, r *http.Request) {
 
 	// Validations
 
-	if !isGameCreated {
-		http.Error(w, createErrorJson("Create a game first"), http.StatusBadRequest)
-		return
-	}
-
-	user := getUserByConnectionId(connectionId)
+	user := userManager.GetUserByConnectionId(connectionId)
 	if user == nil {
 		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
 	}
 
-	if isGameStarted {
-		http.Error(w, createErrorJson("game already started"), http.StatusBadRequest)
-		return
-	}
-
 	// Remove player
-	users = removeUser(user)
+	err = runGameCommand(func(gameHolder *GameHolder) error {
+		if gameHolder.isGameStarted {
+			return ErrGameAlreadyStarted
+		}
 
-	// Un-create game if required
-	if len(users) == 0 {
-		isGameCreated = false
-	}
+		gameHolder.users = removeUser(gameHolder, user)
 
-	appStreamer.Publish(getGameStatusResponse())
-	gameStreamer.Publish(getGameStatusResponse())
+		// Un-create game if required
+		if len(gameHolder.users) == 0 {
+			unCreateGame()
+		}
+
+		appStreamer.Publish(getGameStatusResponse())
+		gameHolder.gameStreamer.Publish(getGameStatusResponse())
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
+		return
+	}
 
 	// Handle response
 	if err := integrateJSONResponse(createSuccessJson(), &w); err != nil {
@@ -333,48 +347,136 @@ func attack(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validations
-	if !isGameCreated {
-		http.Error(w, createErrorJson("game has not been created"), http.StatusBadRequest)
-		return
+	// Update game
+	attackingCardCodes := requestData.AttackingCardCodes
+	if len(attackingCardCodes) == 0 {
+		attackingCardCodes = []string{requestData.AttackingCardCode}
 	}
 
-	if !isGameStarted {
-		http.Error(w, createErrorJson("game has not been started"), http.StatusBadRequest)
+	attackingCards := make([]*game.Card, 0)
+	for _, attackingCardCode := range attackingCardCodes {
+		attackingCard, err := game.NewCardByCode(attackingCardCode)
+
+		if err != nil {
+			handleGameError(w, err)
+			return
+		}
+		attackingCards = append(attackingCards, attackingCard)
+	}
+
+	user := userManager.GetUserByConnectionId(connectionId)
+	if user == nil {
+		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
 	}
+	user.receivedAlive()
 
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		attackingPlayer, err := currentGame.GetPlayerByName(user.name)
 
-	// Update game
-	attackingCard, err := game.NewCardByCode(requestData.AttackingCardCode)
+		if err != nil {
+			return err
+		}
+
+		if err = currentGame.Attack(attackingPlayer, attackingCards...); err != nil {
+			return err
+		}
 
+		handleGameOverIfRequired(gameHolder)
+		gameHolder.gameStreamer.Publish(getUpdateAfterMoveResponse(gameHolder))
+		return nil
+	})
 	if err != nil {
-		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
+		handleGameError(w, err)
 		return
 	}
 
-	user := getUserByConnectionId(connectionId)
-	if user == nil {
-		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
+	// Handle response
+
+	if err = integrateJSONResponse(createSuccessJson(), &w); err != nil {
+		http.Error(w, createErrorJson(err.Error()), 500)
 		return
 	}
-	user.receivedAlive()
+}
+
+func defend(w http.ResponseWriter, r *http.Request) {
 
-	attackingPlayer, err := currentGame.GetPlayerByName(user.name)
+	// Validate request headers
+	allowedMethods := []string{"POST"}
+	if err := validateRequestMethod(&w, r, allowedMethods); err != nil {
+		return
+	}
 
+	// Validate connection id
+	connectionId, err := getConnectionId(r)
 	if err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	if err = currentGame.Attack(attackingPlayer, attackingCard); err != nil {
+	// Parse request
+	requestData := httpPayloadTypes.DefenseRequestObject{}
+	if err := extractJSONData(&requestData, r); err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	// Handle response
+	// Update game
+	defensePairs := requestData.Defences
+	if len(defensePairs) == 0 {
+		defensePairs = []httpPayloadTypes.DefensePairObject{{
+			AttackingCardCode: requestData.AttackingCardCode,
+			DefendingCardCode: requestData.DefendingCardCode,
+		}}
+	}
+
+	defences := make([]*game.Defence, 0)
+	for _, defensePair := range defensePairs {
+		attackingCard, err := game.NewCardByCode(defensePair.AttackingCardCode)
+
+		if err != nil {
+			handleGameError(w, err)
+			return
+		}
+
+		defendingCard, err := game.NewCardByCode(defensePair.DefendingCardCode)
+
+		if err != nil {
+			handleGameError(w, err)
+			return
+		}
+		defences = append(defences, &game.Defence{AttackingCard: attackingCard, DefendingCard: defendingCard})
+	}
+
+	user := userManager.GetUserByConnectionId(connectionId)
+	if user == nil {
+		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
+		return
+	}
+	user.receivedAlive()
 
-	gameStreamer.Publish(getUpdateTurnResponse())
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		defendingPlayer, err := currentGame.GetPlayerByName(user.name)
+
+		if err != nil {
+			return err
+		}
+
+		if err = currentGame.Defend(defendingPlayer, defences...); err != nil {
+			return err
+		}
+
+		gameHolder.gameStreamer.Publish(getUpdateTurnResponse(gameHolder))
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
+		return
+	}
+
+	// Handle response
 
 	if err = integrateJSONResponse(createSuccessJson(), &w); err != nil {
 		http.Error(w, createErrorJson(err.Error()), 500)
@@ -382,7 +484,7 @@ func attack(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func defend(w http.ResponseWriter, r *http.Request) {
+func transfer(w http.ResponseWriter, r *http.Request) {
 
 	// Validate request headers
 	allowedMethods := []string{"POST"}
@@ -398,68 +500,165 @@ func defend(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse request
-	requestData := httpPayloadTypes.DefenseRequestObject{}
+	requestData := httpPayloadTypes.TransferRequestObject{}
 	if err := extractJSONData(&requestData, r); err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	// Validations
-	if !isGameCreated {
-		http.Error(w, createErrorJson("game has not been created"), http.StatusBadRequest)
+	// Update game
+	transferCard, err := game.NewCardByCode(requestData.TransferCardCode)
+
+	if err != nil {
+		handleGameError(w, err)
 		return
 	}
 
-	if !isGameStarted {
-		http.Error(w, createErrorJson("game has not been started"), http.StatusBadRequest)
+	user := userManager.GetUserByConnectionId(connectionId)
+	if user == nil {
+		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
 	}
+	user.receivedAlive()
 
-	// Update game
-	attackingCard, err := game.NewCardByCode(requestData.AttackingCardCode)
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		transferringPlayer, err := currentGame.GetPlayerByName(user.name)
+
+		if err != nil {
+			return err
+		}
+
+		if err = currentGame.Transfer(transferringPlayer, transferCard); err != nil {
+			return err
+		}
 
+		gameHolder.gameStreamer.Publish(getUpdateTurnResponse(gameHolder))
+		return nil
+	})
 	if err != nil {
-		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
+		handleGameError(w, err)
+		return
+	}
+
+	// Handle response
+
+	if err = integrateJSONResponse(createSuccessJson(), &w); err != nil {
+		http.Error(w, createErrorJson(err.Error()), 500)
 		return
 	}
+}
 
-	defendingCard, err := game.NewCardByCode(requestData.DefendingCardCode)
+func takeCards(w http.ResponseWriter, r *http.Request) {
+	// Validate request headers
+	allowedMethods := []string{"POST"}
+	if err := validateRequestMethod(&w, r, allowedMethods); err != nil {
+		return
+	}
 
+	// Validate connection id
+	connectionId, err := getConnectionId(r)
 	if err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	user := getUserByConnectionId(connectionId)
+	// Validations
+
+	user := userManager.GetUserByConnectionId(connectionId)
 	if user == nil {
 		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
 	}
+
 	user.receivedAlive()
 
-	defendingPlayer, err := currentGame.GetPlayerByName(user.name)
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		defendingPlayer, err := currentGame.GetPlayerByName(user.name)
+		if err != nil {
+			return err
+		}
+
+		if err := currentGame.DeclareTake(defendingPlayer); err != nil {
+			return err
+		}
 
+		if currentGame.IsTakeDeclared() {
+			gameHolder.gameStreamer.Publish(getTakeDeclaredResponse(gameHolder))
+		} else {
+			// Nobody could add more cards, so cards were picked up right away
+			handleGameOverIfRequired(gameHolder)
+			gameHolder.gameStreamer.Publish(getUpdateGameResponse(gameHolder))
+		}
+		return nil
+	})
 	if err != nil {
-		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
+		handleGameError(w, err)
+		return
+	}
+
+	// Handle response
+
+	if err := integrateJSONResponse(createSuccessJson(), &w); err != nil {
+		http.Error(w, createErrorJson(err.Error()), 500)
 		return
 	}
+}
 
-	if err = currentGame.Defend(defendingPlayer, attackingCard, defendingCard); err != nil {
+func pass(w http.ResponseWriter, r *http.Request) {
+	// Validate request headers
+	allowedMethods := []string{"POST"}
+	if err := validateRequestMethod(&w, r, allowedMethods); err != nil {
+		return
+	}
+
+	// Validate connection id
+	connectionId, err := getConnectionId(r)
+	if err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	// Handle response
+	// Validations
 
-	gameStreamer.Publish(getUpdateTurnResponse())
+	user := userManager.GetUserByConnectionId(connectionId)
+	if user == nil {
+		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
+		return
+	}
 
-	if err = integrateJSONResponse(createSuccessJson(), &w); err != nil {
+	user.receivedAlive()
+
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		passingPlayer, err := currentGame.GetPlayerByName(user.name)
+		if err != nil {
+			return err
+		}
+
+		if err := currentGame.Pass(passingPlayer); err != nil {
+			return err
+		}
+
+		handleGameOverIfRequired(gameHolder)
+		gameHolder.gameStreamer.Publish(getUpdateAfterMoveResponse(gameHolder))
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
+		return
+	}
+
+	// Handle response
+
+	if err := integrateJSONResponse(createSuccessJson(), &w); err != nil {
 		http.Error(w, createErrorJson(err.Error()), 500)
 		return
 	}
 }
 
-func takeCards(w http.ResponseWriter, r *http.Request) {
+func swapKozer(w http.ResponseWriter, r *http.Request) {
 	// Validate request headers
 	allowedMethods := []string{"POST"}
 	if err := validateRequestMethod(&w, r, allowedMethods); err != nil {
@@ -475,37 +674,93 @@ func takeCards(w http.ResponseWriter, r *http.Request) {
 
 	// Validations
 
-	if !isGameCreated {
-		http.Error(w, createErrorJson("game has not been created"), http.StatusBadRequest)
+	user := userManager.GetUserByConnectionId(connectionId)
+	if user == nil {
+		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
 	}
 
-	if !isGameStarted {
-		http.Error(w, createErrorJson("game has not started"), http.StatusBadRequest)
+	user.receivedAlive()
+
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		swappingPlayer, err := currentGame.GetPlayerByName(user.name)
+		if err != nil {
+			return err
+		}
+
+		if err := currentGame.SwapKozer(swappingPlayer); err != nil {
+			return err
+		}
+
+		gameHolder.gameStreamer.Publish(getKozerSwappedResponse(gameHolder, swappingPlayer.Name))
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
 		return
 	}
 
-	user := getUserByConnectionId(connectionId)
-	if user == nil {
-		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
+	// Handle response
+
+	if err := integrateJSONResponse(createSuccessJson(), &w); err != nil {
+		http.Error(w, createErrorJson(err.Error()), 500)
 		return
 	}
+}
 
-	user.receivedAlive()
-	if !isUserPlaying(user) {
-		http.Error(w, createErrorJson("user is not a player"), http.StatusBadRequest)
+func challenge(w http.ResponseWriter, r *http.Request) {
+	// Validate request headers
+	allowedMethods := []string{"POST"}
+	if err := validateRequestMethod(&w, r, allowedMethods); err != nil {
 		return
 	}
 
-	// Update game
-	if err := currentGame.PickUpCards(); err != nil {
+	// Validate connection id
+	connectionId, err := getConnectionId(r)
+	if err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	// Handle response
+	// Validations
+
+	user := userManager.GetUserByConnectionId(connectionId)
+	if user == nil {
+		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
+		return
+	}
+
+	user.receivedAlive()
+
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		challengingPlayer, err := currentGame.GetPlayerByName(user.name)
+		if err != nil {
+			return err
+		}
+
+		cheater, err := currentGame.Challenge(challengingPlayer)
+		if err != nil {
+			return err
+		}
+
+		cheaterName := ""
+		if cheater != nil {
+			cheaterName = cheater.Name
+		}
+
+		handleGameOverIfRequired(gameHolder)
+		gameHolder.gameStreamer.Publish(getChallengeResponse(challengingPlayer.Name, cheaterName))
+		gameHolder.gameStreamer.Publish(getUpdateGameResponse(gameHolder))
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
+		return
+	}
 
-	gameStreamer.Publish(getUpdateGameResponse())
+	// Handle response
 
 	if err := integrateJSONResponse(createSuccessJson(), &w); err != nil {
 		http.Error(w, createErrorJson(err.Error()), 500)
@@ -513,7 +768,7 @@ func takeCards(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func moveCardsToBita(w http.ResponseWriter, r *http.Request) {
+func redeal(w http.ResponseWriter, r *http.Request) {
 	// Validate request headers
 	allowedMethods := []string{"POST"}
 	if err := validateRequestMethod(&w, r, allowedMethods); err != nil {
@@ -527,17 +782,60 @@ func moveCardsToBita(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !isGameCreated {
-		http.Error(w, createErrorJson("game has not been created"), http.StatusBadRequest)
+	// Validations
+
+	user := userManager.GetUserByConnectionId(connectionId)
+	if user == nil {
+		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
+		return
+	}
+
+	user.receivedAlive()
+
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		requestingPlayer, err := currentGame.GetPlayerByName(user.name)
+		if err != nil {
+			return err
+		}
+
+		if err := currentGame.RequestRedeal(requestingPlayer); err != nil {
+			return err
+		}
+
+		gameHolder.gameStreamer.Publish(getRedealResponse(gameHolder, requestingPlayer.Name))
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
+		return
+	}
+
+	// Handle response
+
+	if err := integrateJSONResponse(createSuccessJson(), &w); err != nil {
+		http.Error(w, createErrorJson(err.Error()), 500)
+		return
+	}
+}
+
+func legalMoves(w http.ResponseWriter, r *http.Request) {
+	// Validate request headers
+	allowedMethods := []string{"GET"}
+	if err := validateRequestMethod(&w, r, allowedMethods); err != nil {
 		return
 	}
 
-	if !isGameStarted {
-		http.Error(w, createErrorJson("game has not started"), http.StatusBadRequest)
+	// Validate connection id
+	connectionId, err := getConnectionId(r)
+	if err != nil {
+		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	user := getUserByConnectionId(connectionId)
+	// Validations
+
+	user := userManager.GetUserByConnectionId(connectionId)
 	if user == nil {
 		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
@@ -545,20 +843,71 @@ func moveCardsToBita(w http.ResponseWriter, r *http.Request) {
 
 	user.receivedAlive()
 
-	if !isUserPlaying(user) {
-		http.Error(w, createErrorJson("user is not a player"), http.StatusBadRequest)
+	// Read game between commands, so moves are not listed for a half updated game
+	var resp httpPayloadTypes.JSONResponseData
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		player, err := currentGame.GetPlayerByName(user.name)
+		if err != nil {
+			return err
+		}
+		resp = getLegalMovesResponse(gameHolder, player)
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
 		return
 	}
 
-	// Update game
-	if err := currentGame.MoveToBita(); err != nil {
+	// Handle response
+
+	if err := integrateJSONResponse(resp, &w); err != nil {
+		http.Error(w, createErrorJson(err.Error()), 500)
+		return
+	}
+}
+
+func moveCardsToBita(w http.ResponseWriter, r *http.Request) {
+	// Validate request headers
+	allowedMethods := []string{"POST"}
+	if err := validateRequestMethod(&w, r, allowedMethods); err != nil {
+		return
+	}
+
+	// Validate connection id
+	connectionId, err := getConnectionId(r)
+	if err != nil {
 		http.Error(w, createErrorJson(err.Error()), http.StatusBadRequest)
 		return
 	}
 
-	// Handle response
+	user := userManager.GetUserByConnectionId(connectionId)
+	if user == nil {
+		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
+		return
+	}
+
+	user.receivedAlive()
+
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		if !isUserPlaying(currentGame, user) {
+			return ErrNotAPlayer
+		}
+
+		if err := currentGame.MoveToBita(); err != nil {
+			return err
+		}
+
+		handleGameOverIfRequired(gameHolder)
+		gameHolder.gameStreamer.Publish(getUpdateGameResponse(gameHolder))
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
+		return
+	}
 
-	gameStreamer.Publish(getUpdateGameResponse())
+	// Handle response
 
 	if err := integrateJSONResponse(createSuccessJson(), &w); err != nil {
 		http.Error(w, createErrorJson(err.Error()), 500)
@@ -580,26 +929,26 @@ func restartGame(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validations
-
-	if !isGameStarted {
-		http.Error(w, createErrorJson("no game running at the moment"), http.StatusBadRequest)
-		return
-	}
+	// Update game, commands are applied one at a time
+	err = runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		if !currentGame.IsGameOver() {
+			return ErrGameNotOver
+		}
 
-	if !currentGame.IsGameOver() {
-		http.Error(w, createErrorJson("game is not over"), http.StatusBadRequest)
-		return
-	}
+		gameHolder.RecordGameResult(currentGame)
+		if err := startGame(gameHolder); err != nil {
+			return err
+		}
 
-	// Update game
-	if err := startGame(); err != nil {
-		http.Error(w, createErrorJson(err.Error()), 500)
+		gameHolder.gameStreamer.Publish(getGameRestartResponse(gameHolder))
+		return nil
+	})
+	if err != nil {
+		handleGameError(w, err)
 		return
 	}
 
 	// Handle response
-	gameStreamer.Publish(getGameRestartResponse())
 
 	if err := integrateJSONResponse(createSuccessJson(), &w); err != nil {
 		http.Error(w, createErrorJson(err.Error()), 500)
@@ -623,7 +972,7 @@ func alive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user := getUserByConnectionId(connId)
+	user := userManager.GetUserByConnectionId(connId)
 	if user == nil {
 		http.Error(w, createErrorJson("Could not find player"), http.StatusBadRequest)
 		return
@@ -670,22 +1019,33 @@ func validateRequestMethod(w *http.ResponseWriter, r *http.Request, allowedMetho
 	return nil
 }
 
-func validateJoinGame() error {
+func validateJoinGame(gameHolder *GameHolder, playerName string) error {
 
-	if !isGameCreated {
-		return errors.New("create a game first")
+	if gameHolder.isGameStarted {
+		return ErrGameAlreadyStarted
 	}
 
-	if isGameStarted {
-		return errors.New("game has already started")
+	for _, u := range gameHolder.users {
+		if u.name == playerName {
+			return ErrNameTaken
+		}
 	}
 
 	return nil
 }
 
 func validateCreateGame(requestData httpPayloadTypes.CreateGameRequestObject) error {
-	if requestData.NumOfPlayers < 2 || requestData.NumOfPlayers > 4 {
-		return errors.New("can not start game with less than 2 players or more than four players")
+	// Game options decide if there are enough cards for all players
+	if requestData.NumOfPlayers < 2 || requestData.NumOfPlayers > 8 {
+		return errors.New("can not start game with less than 2 players or more than eight players")
+	}
+
+	if requestData.Options == nil {
+		return errors.New("game options are missing")
+	}
+
+	if err := requestData.Options.Validate(requestData.NumOfPlayers); err != nil {
+		return err
 	}
 	return nil
 }
@@ -696,16 +1056,10 @@ func validatePlayerName(name string) error {
 		return errors.New("player name contains illegal characters")
 	}
 
-	for _, u := range users {
-		if u.name == name {
-			return errors.New("name already exists")
-		}
-	}
-
 	return nil
 }
 
-func isUserPlaying(user *User) bool {
+func isUserPlaying(currentGame *game.Game, user *User) bool {
 	_, err := currentGame.GetPlayerByName(user.name)
 	return err == nil
 }
@@ -745,6 +1099,55 @@ func isMethodAllowed(request *http.Request, allowedMethods []string) bool {
 	return stringutil.IsStringInSlice(allowedMethods, request.Method)
 }
 
+func runGameCommand(command func(gameHolder *GameHolder) error) error {
+	// All reads and updates of a game and its players go through its command loop
+	gameHolder := gameManager.GetCurrentOpenGame()
+	if gameHolder == nil {
+		return ErrGameNotCreated
+	}
+	return gameHolder.Execute(func() error {
+		return command(gameHolder)
+	})
+}
+
+func runStartedGameCommand(command func(gameHolder *GameHolder, currentGame *game.Game) error) error {
+	return runGameCommand(func(gameHolder *GameHolder) error {
+		if !gameHolder.isGameStarted {
+			return ErrGameNotStarted
+		}
+		return command(gameHolder, gameHolder.game)
+	})
+}
+
+func handleGameError(w http.ResponseWriter, err error) {
+	// Game errors are sent with their code, and with a status matching their kind
+
+	resp := httpPayloadTypes.ErrorResponse{Message: err.Error(), Code: game.GetErrorCode(err), Success: false}
+	js, _ := json.Marshal(resp)
+	http.Error(w, string(js), getGameErrorStatus(err))
+}
+
+func getGameErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, game.ErrPlayerNotFound), errors.Is(err, ErrGameNotCreated):
+		return http.StatusNotFound
+	case errors.Is(err, game.ErrNotAllowed), errors.Is(err, ErrNotAPlayer):
+		return http.StatusForbidden
+	case errors.Is(err, game.ErrNotYourTurn), errors.Is(err, game.ErrWrongPhase), errors.Is(err, game.ErrAlreadyDone),
+		errors.Is(err, game.ErrAttackLimit), errors.Is(err, game.ErrNotPlaying):
+		// Move may be fine, but not in current state of the game
+		return http.StatusConflict
+	case errors.Is(err, ErrGameAlreadyCreated), errors.Is(err, ErrGameNotStarted), errors.Is(err, ErrGameAlreadyStarted),
+		errors.Is(err, ErrGameNotOver), errors.Is(err, ErrNameTaken):
+		// Request may be fine, but not at this stage of the game
+		return http.StatusConflict
+	case errors.Is(err, game.ErrCannotAdd), errors.Is(err, game.ErrCannotBeat), errors.Is(err, game.ErrCannotTransfer),
+		errors.Is(err, game.ErrCardNotInHand):
+		return http.StatusUnprocessableEntity
+	}
+	return http.StatusBadRequest
+}
+
 func createErrorJson(errorMessage string) string {
 	// Default HTTP JSON body error response
 
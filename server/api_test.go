@@ -0,0 +1,189 @@
+package server
+
+import (
+	"DurakGo/game"
+	"DurakGo/server/httpPayloadTypes"
+	"DurakGo/server/stream"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestHandleGameError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{err: ErrGameNotCreated, status: http.StatusNotFound, code: "gameNotCreated"},
+		{err: ErrGameAlreadyCreated, status: http.StatusConflict, code: "gameAlreadyCreated"},
+		{err: ErrGameNotStarted, status: http.StatusConflict, code: "gameNotStarted"},
+		{err: ErrGameAlreadyStarted, status: http.StatusConflict, code: "gameAlreadyStarted"},
+		{err: ErrGameNotOver, status: http.StatusConflict, code: "gameNotOver"},
+		{err: ErrNameTaken, status: http.StatusConflict, code: "nameTaken"},
+		{err: ErrNotAPlayer, status: http.StatusForbidden, code: "notAPlayer"},
+		{err: game.ErrWrongPhase, status: http.StatusConflict, code: "wrongPhase"},
+		{err: errors.New("request is not valid"), status: http.StatusBadRequest, code: ""},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		handleGameError(w, test.err)
+
+		resp := httpPayloadTypes.ErrorResponse{}
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		if w.Code != test.status || resp.Code != test.code || resp.Message != test.err.Error() {
+			t.Fatalf("%q sent with status %d and code %q, expected %d and %q", resp.Message, w.Code, resp.Code,
+				test.status, test.code)
+		}
+	}
+}
+
+func setUpTestServer() {
+	// Same managers InitServer sets up, without listening or watching for dead users
+	aliveTTL := configuration.GetInt("AliveTTL")
+	gameManager = NewGameManager()
+	userManager = NewUserManager(aliveTTL)
+	appStreamer = stream.NewAppStreamer(getIsAliveResponse(), aliveTTL)
+}
+
+func sendTestRequest(handler http.HandlerFunc, method string, connectionId string, body interface{}) *httptest.ResponseRecorder {
+	var reader io.Reader = http.NoBody
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+
+	r := httptest.NewRequest(method, "/", reader)
+	r.Header.Set("ConnectionId", connectionId)
+	w := httptest.NewRecorder()
+	handler(w, r)
+	return w
+}
+
+func sendTestMove(connectionId string, move *game.Move) *httptest.ResponseRecorder {
+	getCode := func(card *game.Card) string {
+		code, _ := game.CardToCode(card)
+		return code
+	}
+
+	switch move.Type {
+	case game.MoveAttack:
+		return sendTestRequest(attack, "POST", connectionId,
+			httpPayloadTypes.AttackRequestObject{AttackingCardCode: getCode(move.Card)})
+	case game.MoveDefend:
+		return sendTestRequest(defend, "POST", connectionId, httpPayloadTypes.DefenseRequestObject{
+			AttackingCardCode: getCode(move.Defence.AttackingCard), DefendingCardCode: getCode(move.Defence.DefendingCard)})
+	case game.MoveTransfer:
+		return sendTestRequest(transfer, "POST", connectionId,
+			httpPayloadTypes.TransferRequestObject{TransferCardCode: getCode(move.Card)})
+	case game.MovePass:
+		return sendTestRequest(pass, "POST", connectionId, nil)
+	case game.MoveTake:
+		return sendTestRequest(takeCards, "POST", connectionId, nil)
+	default:
+		return sendTestRequest(moveCardsToBita, "POST", connectionId, nil)
+	}
+}
+
+func TestParallelMovesThroughHandlers(t *testing.T) {
+	// Players create, join and play a game at the same time, through the handlers and the game holder
+	setUpTestServer()
+	defer unCreateGame()
+
+	names := []string{"a", "b", "c", "d"}
+	connectionIds := make([]string, 0)
+	for range names {
+		resp := httpPayloadTypes.GetConnectionIdResponse{}
+		w := sendTestRequest(createConnectionId, "GET", "", nil)
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.ConnectionId == "" {
+			t.Fatalf("no connection id in %s", w.Body)
+		}
+		connectionIds = append(connectionIds, resp.ConnectionId)
+	}
+
+	options := game.NewDefaultGameOptions()
+	options.Variant = game.Perevodnoy
+	options.Seed = 25
+	w := sendTestRequest(createGame, "POST", connectionIds[0],
+		httpPayloadTypes.CreateGameRequestObject{NumOfPlayers: len(names), PlayerName: names[0], Options: options})
+	if w.Code != http.StatusOK {
+		t.Fatalf("could not create game: %s", w.Body)
+	}
+
+	var wg sync.WaitGroup
+	for i := 1; i < len(names); i++ {
+		wg.Add(1)
+		go func(name string, connectionId string) {
+			defer wg.Done()
+			w := sendTestRequest(joinGame, "POST", connectionId, httpPayloadTypes.JoinGameRequestObject{PlayerName: name})
+			if w.Code != http.StatusOK {
+				t.Errorf("%s could not join game: %s", name, w.Body)
+			}
+		}(names[i], connectionIds[i])
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	isGameOver := func() bool {
+		isOver := false
+		if err := runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+			isOver = currentGame.IsGameOver()
+			return nil
+		}); err != nil {
+			t.Error(err)
+			return true
+		}
+		return isOver
+	}
+
+	for i, name := range names {
+		wg.Add(1)
+		go func(name string, connectionId string, random *rand.Rand) {
+			defer wg.Done()
+			for numOfRequests := 0; numOfRequests < 5000; numOfRequests++ {
+				w := sendTestRequest(legalMoves, "GET", connectionId, nil)
+				resp := httpPayloadTypes.LegalMovesResponse{}
+				if err := json.Unmarshal(w.Body.Bytes(), &resp); w.Code != http.StatusOK || err != nil {
+					t.Errorf("could not get legal moves of %s: %s", name, w.Body)
+					return
+				}
+
+				if len(resp.Moves) == 0 {
+					if isGameOver() {
+						return
+					}
+					continue
+				}
+
+				// Other players may have moved since moves were listed
+				w = sendTestMove(connectionId, resp.Moves[random.Intn(len(resp.Moves))])
+				if w.Code != http.StatusOK && w.Code != http.StatusConflict && w.Code != http.StatusUnprocessableEntity {
+					t.Errorf("move of %s failed with %d: %s", name, w.Code, w.Body)
+					return
+				}
+			}
+			t.Errorf("game is not over after 5000 requests of %s", name)
+		}(name, connectionIds[i], rand.New(rand.NewSource(int64(i))))
+	}
+	wg.Wait()
+
+	if err := runStartedGameCommand(func(gameHolder *GameHolder, currentGame *game.Game) error {
+		if !currentGame.IsGameOver() || gameHolder.match.numOfGamesPlayed != 1 {
+			return errors.New("game must be over and recorded once players stopped moving")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,85 @@
+package commandLoop
+
+import (
+	"DurakGo/output"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// Applies commands one at a time on a single goroutine, in the order they arrive.
+// Game engine is not safe for concurrent use, so every read and update of a game is sent as a command.
+// Commands must not execute other commands of the same loop, they would wait for themselves.
+
+var ErrLoopClosed = errors.New("command loop is closed")
+
+type command struct {
+	apply func() error
+	result chan error
+}
+
+type CommandLoop struct {
+	name string
+	commands chan *command
+	closed chan struct{}
+	closeOnce sync.Once
+}
+
+func NewCommandLoop(name string) *CommandLoop {
+	loop := &CommandLoop{
+		name: name,
+		commands: make(chan *command),
+		closed: make(chan struct{}),
+	}
+
+	go loop.loop()
+	return loop
+}
+
+func (this *CommandLoop) Execute(apply func() error) error {
+	// Blocks until the command is applied, returns the error of the command
+	command := &command{apply: apply, result: make(chan error, 1)}
+
+	select {
+	case this.commands <- command:
+		return <-command.result
+	case <-this.closed:
+		return ErrLoopClosed
+	}
+}
+
+func (this *CommandLoop) Close() {
+	// Stops the loop, commands sent afterwards are rejected
+	this.closeOnce.Do(func() {
+		close(this.closed)
+	})
+}
+
+func (this *CommandLoop) loop() {
+	output.Spit(fmt.Sprintf("go routine - %s commands - start", this.name))
+	defer func() {
+		output.Spit(fmt.Sprintf("go routine - %s commands - ended", this.name))
+	}()
+
+	for {
+		select {
+		case command := <-this.commands:
+			command.result <- this.apply(command)
+		case <-this.closed:
+			return
+		}
+	}
+}
+
+func (this *CommandLoop) apply(command *command) (err error) {
+	// A panicking command fails on its own, like a panicking HTTP handler does, and the loop goes on
+	defer func() {
+		if r := recover(); r != nil {
+			output.Spit(fmt.Sprintf("%s command panicked: %v\n%s", this.name, r, debug.Stack()))
+			err = fmt.Errorf("command failed: %v", r)
+		}
+	}()
+
+	return command.apply()
+}
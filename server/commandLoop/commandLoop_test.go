@@ -0,0 +1,174 @@
+package commandLoop
+
+import (
+	"DurakGo/game"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestParallelExecute(t *testing.T) {
+	loop := NewCommandLoop("test")
+	defer loop.Close()
+
+	counter := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := loop.Execute(func() error {
+					counter++
+					return nil
+				}); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := loop.Execute(func() error {
+		if counter != 5000 {
+			return fmt.Errorf("expected 5000 commands to be applied, got %d", counter)
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPanickingCommand(t *testing.T) {
+	loop := NewCommandLoop("test")
+	defer loop.Close()
+
+	err := loop.Execute(func() error {
+		var player *game.Player
+		return fmt.Errorf("unreachable %s", player.Name)
+	})
+	if err == nil {
+		t.Fatal("panicking command must fail")
+	}
+
+	commandErr := errors.New("command error")
+	if err := loop.Execute(func() error { return commandErr }); err != commandErr {
+		t.Fatalf("loop must go on after a panic, got %v", err)
+	}
+}
+
+func TestExecuteAfterClose(t *testing.T) {
+	loop := NewCommandLoop("test")
+	loop.Close()
+	loop.Close()
+
+	isApplied := false
+	if err := loop.Execute(func() error {
+		isApplied = true
+		return nil
+	}); err != ErrLoopClosed {
+		t.Fatalf("expected %v, got %v", ErrLoopClosed, err)
+	}
+	if isApplied {
+		t.Fatal("command must not be applied once loop is closed")
+	}
+}
+
+func playMove(currentGame *game.Game, player *game.Player, move *game.Move) error {
+	switch move.Type {
+	case game.MoveAttack:
+		return currentGame.Attack(player, move.Card)
+	case game.MoveDefend:
+		return currentGame.Defend(player, move.Defence)
+	case game.MoveTransfer:
+		return currentGame.Transfer(player, move.Card)
+	case game.MovePass:
+		return currentGame.Pass(player)
+	case game.MoveTake:
+		return currentGame.DeclareTake(player)
+	case game.MoveBita:
+		return currentGame.MoveToBita()
+	}
+	return fmt.Errorf("unknown move %s", move.Type)
+}
+
+func TestParallelMoves(t *testing.T) {
+	// Players move at the same time and responses are encoded outside the loop, like HTTP handlers do
+	loop := NewCommandLoop("test")
+	defer loop.Close()
+
+	names := []string{"a", "b", "c", "d"}
+	options := game.NewDefaultGameOptions()
+	options.Variant = game.Perevodnoy
+	options.Seed = 25
+	currentGame, err := game.NewGame(options, names...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(name string, random *rand.Rand) {
+			defer wg.Done()
+			for numOfMoves := 0; numOfMoves < 2000; {
+				isGameOver := false
+				var playerCards map[string][]*game.Card
+				var cardsOnBoard []*game.CardOnBoard
+
+				if err := loop.Execute(func() error {
+					isGameOver = currentGame.IsGameOver()
+					player, err := currentGame.GetPlayerByName(name)
+					if err != nil || isGameOver {
+						return nil
+					}
+
+					moves := currentGame.LegalMoves(player)
+					if len(moves) > 0 {
+						if err := playMove(currentGame, player, moves[random.Intn(len(moves))]); err != nil {
+							return err
+						}
+						numOfMoves++
+					}
+
+					playerCards = currentGame.GetPlayersCardsMap()
+					cardsOnBoard = currentGame.GetCardsOnBoard()
+					return nil
+				}); err != nil {
+					t.Error(err)
+					return
+				}
+
+				if isGameOver {
+					return
+				}
+				if _, err := json.Marshal(playerCards); err != nil {
+					t.Error(err)
+					return
+				}
+				boardCards := make([]*game.Card, 0)
+				for _, cardOnBoard := range cardsOnBoard {
+					boardCards = append(boardCards, cardOnBoard.GetAttackingCard(), cardOnBoard.GetDefendingCard())
+				}
+				if _, err := json.Marshal(boardCards); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}(name, rand.New(rand.NewSource(int64(i))))
+	}
+	wg.Wait()
+
+	if err := loop.Execute(func() error {
+		if !currentGame.IsGameOver() {
+			return errors.New("game must be over once players stopped moving")
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
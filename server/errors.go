@@ -0,0 +1,15 @@
+package server
+
+import "DurakGo/game"
+
+// Errors of game creation and joining, coded like game errors so clients handle both the same way
+
+var (
+	ErrGameNotCreated     = game.NewGameError("gameNotCreated", "game has not been created")
+	ErrGameAlreadyCreated = game.NewGameError("gameAlreadyCreated", "game has already been created")
+	ErrGameNotStarted     = game.NewGameError("gameNotStarted", "game has not started")
+	ErrGameAlreadyStarted = game.NewGameError("gameAlreadyStarted", "game has already started")
+	ErrGameNotOver        = game.NewGameError("gameNotOver", "game is not over")
+	ErrNameTaken          = game.NewGameError("nameTaken", "name already exists")
+	ErrNotAPlayer         = game.NewGameError("notAPlayer", "user is not a player")
+)
@@ -2,24 +2,107 @@ package server
 
 import (
 	"DurakGo/game"
+	"DurakGo/server/commandLoop"
 	"DurakGo/server/stream"
+	"fmt"
 )
 
+// Game and players of the holder are read and updated only by commands, see Execute
+
 type GameHolder struct {
 	ID int
 	users []*User
 	game *game.Game
 	isGameStarted bool
 	numOfPlayers int
+	options *game.GameOptions
+	match *MatchContext
 	gameStreamer *stream.GameStreamer
+	commands *commandLoop.CommandLoop  // Every read and update of the game goes through it
+}
+
+// Data kept between games played by the same players
+
+type MatchContext struct {
+	previousDurakName string
+	lastRecordedGame *game.Game
+	scores map[string]int  // Penalty points, durak gets one point and another one for pogony
+	numOfGamesPlayed int
 }
 
-func NewGameHolder(id int, playerNum int) *GameHolder{
-	return &GameHolder{
+func NewGameHolder(id int, playerNum int, options *game.GameOptions) *GameHolder{
+	holder := &GameHolder{
 		ID: id,
 		isGameStarted: false,
 		numOfPlayers: playerNum,
-		gameStreamer: stream.NewGameStreamer()
+		options: options,
+		match: &MatchContext{scores: make(map[string]int)},
+		gameStreamer: stream.NewGameStreamer(getIsAliveResponse(), configuration.GetInt("AliveTTL")),
+		commands: commandLoop.NewCommandLoop(fmt.Sprintf("game %d", id)),
+	}
+
+	return holder
+}
+
+func (this *GameHolder) Execute(apply func() error) error {
+	// Blocks until the command is applied, returns the error of the command
+	return this.commands.Execute(apply)
+}
+
+func (this *GameHolder) Close() {
+	// Commands sent afterwards are rejected
+	this.commands.Close()
+}
+
+func (this *GameHolder) RecordGameResult(finishedGame *game.Game) {
+	// Safe to call more than once per game
+
+	if this.match.lastRecordedGame == finishedGame || !finishedGame.IsGameOver() {
+		return
 	}
+	this.match.lastRecordedGame = finishedGame
+	this.match.numOfGamesPlayed++
 
+	// Player left with cards after the others left did not lose, so the game is not scored
+	// and durak of the last played out game stays
+	if finishedGame.IsAbandoned() {
+		return
+	}
+
+	durakName := finishedGame.GetLosingPlayerName()
+	this.match.previousDurakName = durakName
+
+	// Both partners of a losing team are scored
+	losingPlayerNames := finishedGame.GetLosingTeamPlayerNames()
+	if durakName != "" {
+		losingPlayerNames = []string{durakName}
+	}
+
+	for _, name := range losingPlayerNames {
+		this.match.scores[name]++
+		if finishedGame.IsPogony() {
+			this.match.scores[name]++
+		}
+	}
+}
+
+func (this *GameHolder) GetMatchScores() map[string]int {
+	// Copy, scores keep changing while responses are sent
+	scores := make(map[string]int)
+	for name, score := range this.match.scores {
+		scores[name] = score
+	}
+	return scores
+}
+
+func (this *GameHolder) GetNextGameOptions() *game.GameOptions {
+	// Copy of holder options, completed with match context
+	options := *this.options
+	options.PreviousDurakName = this.match.previousDurakName
+
+	// Each game of a seeded match gets its own seed, the same seed would deal the same deck again
+	if options.Seed != 0 {
+		options.Seed += int64(this.match.numOfGamesPlayed)
+	}
+	return &options
 }
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"DurakGo/game"
+	"testing"
+)
+
+func newTestGame(t *testing.T, state *game.GameState) *game.Game {
+	t.Helper()
+	newGame, err := game.NewGameFromState(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return newGame
+}
+
+func getTestCards(t *testing.T, codes ...string) []*game.Card {
+	t.Helper()
+	cards := make([]*game.Card, 0)
+	for _, code := range codes {
+		card, err := game.NewCardByCode(code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cards = append(cards, card)
+	}
+	return cards
+}
+
+func newPlayedOutGame(t *testing.T) *game.Game {
+	// b is left holding sixes
+	finishedGame := newTestGame(t, &game.GameState{
+		Players: []*game.PlayerState{
+			{Name: "a", Cards: getTestCards(t, "7C")},
+			{Name: "b", Cards: getTestCards(t, "8C", "6S", "6D")},
+		},
+		KozerCard:           getTestCards(t, "AS")[0],
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+
+	a, _ := finishedGame.GetPlayerByName("a")
+	b, _ := finishedGame.GetPlayerByName("b")
+	if err := finishedGame.Attack(a, getTestCards(t, "7C")...); err != nil {
+		t.Fatal(err)
+	}
+	if err := finishedGame.Defend(b, &game.Defence{AttackingCard: getTestCards(t, "7C")[0],
+		DefendingCard: getTestCards(t, "8C")[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := finishedGame.MoveToBita(); err != nil {
+		t.Fatal(err)
+	}
+	return finishedGame
+}
+
+func newAbandonedGame(t *testing.T, leavingPlayerName string) *game.Game {
+	// a holds a seven and b holds sixes when one of them leaves
+	abandonedGame := newTestGame(t, &game.GameState{
+		Players: []*game.PlayerState{
+			{Name: "a", Cards: getTestCards(t, "7C")},
+			{Name: "b", Cards: getTestCards(t, "6S", "6D")},
+		},
+		KozerCard:           getTestCards(t, "AS")[0],
+		StartingPlayerName:  "a",
+		DefendingPlayerName: "b",
+	})
+
+	if err := abandonedGame.HandlePlayerLeft(leavingPlayerName); err != nil {
+		t.Fatal(err)
+	}
+	if !abandonedGame.IsGameOver() {
+		t.Fatalf("game must be over once %s leaves", leavingPlayerName)
+	}
+	return abandonedGame
+}
+
+func TestAbandonedGameKeepsPreviousDurak(t *testing.T) {
+	gameHolder := NewGameHolder(1, 2, game.NewDefaultGameOptions())
+	defer gameHolder.Close()
+
+	gameHolder.RecordGameResult(newPlayedOutGame(t))
+	if gameHolder.match.previousDurakName != "b" {
+		t.Fatalf("expected b to be previous durak, got %s", gameHolder.match.previousDurakName)
+	}
+
+	// a is left with cards only because b left
+	gameHolder.RecordGameResult(newAbandonedGame(t, "b"))
+	if options := gameHolder.GetNextGameOptions(); options.PreviousDurakName != "b" {
+		t.Fatalf("abandoned game must not change previous durak, got %s", options.PreviousDurakName)
+	}
+}
+
+func TestAbandonedGameIsNotScored(t *testing.T) {
+	gameHolder := NewGameHolder(1, 2, game.NewDefaultGameOptions())
+	defer gameHolder.Close()
+
+	// b is left holding sixes only because a left
+	gameHolder.RecordGameResult(newAbandonedGame(t, "a"))
+	if scores := gameHolder.GetMatchScores(); len(scores) != 0 {
+		t.Fatalf("abandoned game must not be scored, got %v", scores)
+	}
+
+	gameHolder.RecordGameResult(newPlayedOutGame(t))
+	if scores := gameHolder.GetMatchScores(); len(scores) != 1 || scores["b"] != 2 {
+		t.Fatalf("expected b to get a point and another one for pogony, got %v", scores)
+	}
+}
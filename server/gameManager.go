@@ -1,6 +1,7 @@
 package server
 
 import (
+	"DurakGo/game"
 	"sync"
 )
 
@@ -20,7 +21,7 @@ func NewGameManager() *GameManager {
 	}
 }
 
-func (this *GameManager) CreateNewGame(playerNum int) *GameHolder {
+func (this *GameManager) CreateNewGame(playerNum int, options *game.GameOptions) *GameHolder {
 	this.gameCreatorLock.Lock()
 	defer func() { this.gameCreatorLock.Unlock() }()
 
@@ -28,9 +29,21 @@ func (this *GameManager) CreateNewGame(playerNum int) *GameHolder {
 		return nil
 	} else {
 		this.lastIdUsed++
-		gameHolder := NewGameHolder(this.lastIdUsed, playerNum)
+		gameHolder := NewGameHolder(this.lastIdUsed, playerNum, options)
 		this.currentOpenGame = gameHolder
 		this.games = append(this.games, this.currentOpenGame)
+		return gameHolder
+	}
+}
+
+func (this *GameManager) UncreateGame() {
+	// Commands sent to the game afterwards are rejected
+	this.gameCreatorLock.Lock()
+	defer func() { this.gameCreatorLock.Unlock() }()
+
+	if this.currentOpenGame != nil {
+		this.currentOpenGame.Close()
+		this.currentOpenGame = nil
 	}
 }
 
@@ -39,4 +52,11 @@ func (this *GameManager) IsGameCreated() bool {
 	defer func() { this.gameCreatorLock.Unlock() }()
 
 	return this.currentOpenGame != nil
+}
+
+func (this *GameManager) GetCurrentOpenGame() *GameHolder {
+	this.gameCreatorLock.Lock()
+	defer func() { this.gameCreatorLock.Unlock() }()
+
+	return this.currentOpenGame
 }
\ No newline at end of file
@@ -1,10 +1,13 @@
 package httpPayloadTypes
 
+import "DurakGo/game"
+
 type JSONRequestPayload interface {}
 
 type CreateGameRequestObject struct {
 	NumOfPlayers int `json:"numOfPlayers"`
 	PlayerName string `json:"playerName"`
+	Options *game.GameOptions `json:"options"`
 }
 
 type JoinGameRequestObject struct {
@@ -13,9 +16,20 @@ type JoinGameRequestObject struct {
 
 type AttackRequestObject struct {
 	AttackingCardCode string `json:"attackingCardCode"`
+	AttackingCardCodes []string `json:"attackingCardCodes"`  // For attacking with several cards at once
 }
 
 type DefenseRequestObject struct {
 	DefendingCardCode string `json:"defendingCardCode"`
 	AttackingCardCode string `json:"attackingCardCode"`
+	Defences []DefensePairObject `json:"defences"`  // For defending several cards at once
+}
+
+type DefensePairObject struct {
+	DefendingCardCode string `json:"defendingCardCode"`
+	AttackingCardCode string `json:"attackingCardCode"`
+}
+
+type TransferRequestObject struct {
+	TransferCardCode string `json:"transferCardCode"`
 }
\ No newline at end of file
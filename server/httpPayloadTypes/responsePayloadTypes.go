@@ -11,6 +11,11 @@ type GameStatusResponse struct {
 type TurnUpdateResponse struct {
 	PlayerCards map[string][]*game.Card `json:"playerCards"`
 	CardsOnTable []*game.CardOnBoard `json:"cardsOnTable"`
+	PlayerStartingName string `json:"playerStarting"`
+	PlayerDefendingName string `json:"playerDefending"`
+	PlayerTransferringName string `json:"playerTransferring"`  // Empty if attack was not transferred
+	Phase game.Phase `json:"phase"`
+	PassedPlayerNames []string `json:"passedPlayers"`
 }
 
 type GameUpdateResponse struct {
@@ -21,7 +26,16 @@ type GameUpdateResponse struct {
 	PlayerDefendingName  string                  `json:"playerDefending"`
 	GameOver             bool                    `json:"gameOver"`
 	IsDraw               bool                    `json:"isDraw"`
+	IsAbandoned          bool                    `json:"isAbandoned"`  // Ended by players leaving, nobody lost
 	LosingPlayerName     string                  `json:"losingPlayerName"`
+	LosingTeam           []string                `json:"losingTeam"`
+	LosingPlayerFinalHand []*game.Card           `json:"losingPlayerFinalHand"`  // Whole losing team in team games
+	LosingHands          map[string][]*game.Card `json:"losingHands"`
+	IsPogony             bool                    `json:"isPogony"`
+	MatchScores          map[string]int          `json:"matchScores"`
+	Phase                game.Phase              `json:"phase"`
+	DeckOrder            []string                `json:"deckOrder"`  // Revealed when game is over
+	DeckSalt             string                  `json:"deckSalt"`
 }
 
 type StartGameResponse struct {
@@ -32,6 +46,10 @@ type StartGameResponse struct {
 	PlayerDefendingName  string                  `json:"playerDefending"`
 	CardsOnTable         []*game.CardOnBoard     `json:"cardsOnTable"`
 	Players				 []string				 `json:"players"`
+	Options              *game.GameOptions       `json:"options"`
+	PlayerTeams          map[string]int          `json:"playerTeams"`
+	RedealPlayerNames    []string                `json:"redealPlayers"`
+	DeckCommitment       string                  `json:"deckCommitment"`
 }
 
 type GameRestartResponse struct {
@@ -43,6 +61,41 @@ type GameRestartResponse struct {
 	PlayerDefendingName  string                  `json:"playerDefending"`
 	GameOver             bool                    `json:"gameOver"`
 	IsDraw               bool                    `json:"isDraw"`
+	Options              *game.GameOptions       `json:"options"`
+	DeckCommitment       string                  `json:"deckCommitment"`
+}
+
+type TakeDeclaredResponse struct {
+	PlayerDefendingName string              `json:"playerDefending"`
+	CardsOnTable        []*game.CardOnBoard `json:"cardsOnTable"`
+	Phase               game.Phase          `json:"phase"`
+}
+
+type RedealResponse struct {
+	PlayerCards          map[string][]*game.Card `json:"playerCards"`
+	KozerCard            *game.Card              `json:"kozerCard"`
+	NumOfCardsLeftInDeck int                     `json:"numOfCardsLeftInDeck"`
+	PlayerStartingName   string                  `json:"playerStarting"`
+	PlayerDefendingName  string                  `json:"playerDefending"`
+	RequestingPlayerName string                  `json:"requestingPlayer"`
+	RedealPlayerNames    []string                `json:"redealPlayers"`
+	DeckCommitment       string                  `json:"deckCommitment"`
+}
+
+type KozerSwappedResponse struct {
+	PlayerCards map[string][]*game.Card `json:"playerCards"`
+	KozerCard   *game.Card              `json:"kozerCard"`
+	PlayerName  string                  `json:"playerName"`
+}
+
+type ChallengeResponse struct {
+	ChallengerName string `json:"challenger"`
+	CheaterName    string `json:"cheater"`  // Empty if challenge was false
+	IsSuccessful   bool   `json:"isSuccessful"`
+}
+
+type LegalMovesResponse struct {
+	Moves []*game.Move `json:"moves"`
 }
 
 type PlayerJoinedResponse struct {}
@@ -56,6 +109,7 @@ type GetConnectionIdResponse struct {
 type ErrorResponse struct {
 	Success bool `json:"success"`
 	Message string `json:"message"`
+	Code string `json:"code"`  // Game error code, empty for other errors
 }
 
 type SuccessResponse struct {
@@ -100,4 +154,20 @@ func (this *GameRestartResponse) GetPlayerCards() map[string][]*game.Card {
 
 func (this *GameRestartResponse) SetPlayerCards(m *map[string][]*game.Card)  {
 	this.PlayerCards = *m
+}
+
+func (this *KozerSwappedResponse) GetPlayerCards() map[string][]*game.Card {
+	return this.PlayerCards
+}
+
+func (this *KozerSwappedResponse) SetPlayerCards(m *map[string][]*game.Card)  {
+	this.PlayerCards = *m
+}
+
+func (this *RedealResponse) GetPlayerCards() map[string][]*game.Card {
+	return this.PlayerCards
+}
+
+func (this *RedealResponse) SetPlayerCards(m *map[string][]*game.Card)  {
+	this.PlayerCards = *m
 }
\ No newline at end of file
@@ -26,7 +26,7 @@ func InitServer(conf *config.Configuration) {
 	output.Spit("Server initialized!")
 	aliveTTL := conf.GetInt("AliveTTL")
 	gameManager = NewGameManager()
-	userManager = NewUserManager()
+	userManager = NewUserManager(aliveTTL)
 	appStreamer = stream.NewAppStreamer(getIsAliveResponse(), aliveTTL)
 
 	go handleDeadUsers()
@@ -41,7 +41,13 @@ func InitServer(conf *config.Configuration) {
 	http.HandleFunc("/leaveGame", leaveGame)
 	http.HandleFunc("/attack", attack)
 	http.HandleFunc("/defend", defend)
+	http.HandleFunc("/transfer", transfer)
 	http.HandleFunc("/takeCards", takeCards)
+	http.HandleFunc("/pass", pass)
+	http.HandleFunc("/swapKozer", swapKozer)
+	http.HandleFunc("/redeal", redeal)
+	http.HandleFunc("/challenge", challenge)
+	http.HandleFunc("/legalMoves", legalMoves)
 	http.HandleFunc("/moveCardsToBita", moveCardsToBita)
 	http.HandleFunc("/restartGame", restartGame)
 
@@ -54,51 +60,53 @@ func InitServer(conf *config.Configuration) {
 func unCreateGame() {
 	output.Spit("Uncreating game")
 	gameManager.UncreateGame()
-	//users = make([]*User, 0)
-	isGameCreated = false
-	if isGameStarted {
-		currentGame = nil
-		isGameStarted = false
-	}
-
 }
 
-func startGame() error {
+// Functions taking the game holder are called inside its commands
+
+func startGame(gameHolder *GameHolder) error {
 
 	playerNames := make([]string, 0)
 
-	for _, u := range users {
+	for _, u := range gameHolder.users {
 		playerNames = append(playerNames, u.name)
 	}
 
 	output.Spit("Starting game!")
 
-	newGame, err := game.NewGame(playerNames...)
+	newGame, err := game.NewGame(gameHolder.GetNextGameOptions(), playerNames...)
 
 	if err != nil {
 		return err
 	}
-	currentGame = newGame
-	isGameStarted = true
+	gameHolder.game = newGame
+	gameHolder.isGameStarted = true
 	return nil
 }
 
-func handlePlayerJoin(user *User) error {
+func handleGameOverIfRequired(gameHolder *GameHolder) {
+	if gameHolder.game.IsGameOver() {
+		gameHolder.RecordGameResult(gameHolder.game)
+	}
+}
+
+func handlePlayerJoin(gameHolder *GameHolder, user *User) error {
 	output.Spit(fmt.Sprintf("User %s generated Player %s and joined to game", user.connectionId, user.name))
 	user.isJoined = true
+	gameHolder.users = append(gameHolder.users, user)
 
 	// Start game if required
-	if getNumOfJoinedUsers() == numOfPlayers {
-		if err := startGame(); err != nil {
+	if getNumOfJoinedUsers(gameHolder) == gameHolder.numOfPlayers {
+		if err := startGame(gameHolder); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func getNumOfJoinedUsers() int {
+func getNumOfJoinedUsers(gameHolder *GameHolder) int {
 	i := 0
-	for _, u := range users {
+	for _, u := range gameHolder.users {
 		if u.isJoined {
 			i++
 		}
@@ -106,14 +114,14 @@ func getNumOfJoinedUsers() int {
 	return i
 }
 
-func removeUser(u *User) []*User {
+func removeUser(gameHolder *GameHolder, u *User) []*User {
 	output.Spit(fmt.Sprintf("Removing user %s", u))
-	for i, user := range users {
+	for i, user := range gameHolder.users {
 		if user == u {
-			return append(users[:i], users[i+1:]...)
+			return append(gameHolder.users[:i], gameHolder.users[i+1:]...)
 		}
 	}
-	return users
+	return gameHolder.users
 }
 
 func getCustomizedPlayerCards(respData httpPayloadTypes.CustomizableJSONResponseData,
@@ -164,6 +172,18 @@ func customizeDataPerPlayer(playerName string) func(httpPayloadTypes.JSONRespons
 				return nil, err
 			}
 			return copiedObj, nil
+		case *httpPayloadTypes.RedealResponse:
+			copiedObj := &httpPayloadTypes.RedealResponse{}
+			if err := helperFunc(val, copiedObj, playerName); err != nil {
+				return nil, err
+			}
+			return copiedObj, nil
+		case *httpPayloadTypes.KozerSwappedResponse:
+			copiedObj := &httpPayloadTypes.KozerSwappedResponse{}
+			if err := helperFunc(val, copiedObj, playerName); err != nil {
+				return nil, err
+			}
+			return copiedObj, nil
 		case *httpPayloadTypes.TurnUpdateResponse:
 			copiedObj := &httpPayloadTypes.TurnUpdateResponse{}
 			if err := helperFunc(val, copiedObj, playerName); err != nil {
@@ -183,30 +203,33 @@ func handleDeadUsers() {
 	}()
 
 	for {
-		deadUser := <-notAliveUser
+		deadUser := <-userManager.notAliveChan
 		output.Spit(fmt.Sprintf("User %s is dead. Removing from app stream", deadUser))
 		appStreamer.RemoveClient(deadUser.appChan)
-		if isGameCreated {
+
+		// Fails when no game is created
+		runGameCommand(func(gameHolder *GameHolder) error {
 			output.Spit(fmt.Sprintf("User %s is dead. Removing from game stream", deadUser))
-			appStreamer.RemoveClient(deadUser.gameChan)
-			if ! isGameStarted {
+			gameHolder.gameStreamer.RemoveClient(deadUser.gameChan)
+			if !gameHolder.isGameStarted {
 
-				users = removeUser(deadUser)
+				gameHolder.users = removeUser(gameHolder, deadUser)
 
 				// Un-create game if required
-				if len(users) == 0 {
-					isGameCreated = false
+				if len(gameHolder.users) == 0 {
+					unCreateGame()
 				}
 
 				appStreamer.Publish(getGameStatusResponse())
-			} else {
-				playerName := deadUser.name
-				if err := currentGame.HandlePlayerLeft(playerName); err != nil {
-					// TODO What is missing here???
-				}
-				gameStreamer.Publish(getUpdateGameResponse())
+				return nil
 			}
-		}
 
+			if err := gameHolder.game.HandlePlayerLeft(deadUser.name); err != nil {
+				// TODO What is missing here???
+			}
+			handleGameOverIfRequired(gameHolder)
+			gameHolder.gameStreamer.Publish(getUpdateGameResponse(gameHolder))
+			return nil
+		})
 	}
 }
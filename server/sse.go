@@ -1,8 +1,12 @@
 package server
 
-import "DurakGo/server/httpPayloadTypes"
+import (
+	"DurakGo/game"
+	"DurakGo/server/httpPayloadTypes"
+)
 
-func getUpdateGameResponse() httpPayloadTypes.JSONResponseData {
+func getUpdateGameResponse(gameHolder *GameHolder) httpPayloadTypes.JSONResponseData {
+	currentGame := gameHolder.game
 	resp := &httpPayloadTypes.GameUpdateResponse{
 		PlayerCards:          currentGame.GetPlayersCardsMap(),
 		CardsOnTable:         currentGame.GetCardsOnBoard(),
@@ -11,22 +15,104 @@ func getUpdateGameResponse() httpPayloadTypes.JSONResponseData {
 		PlayerDefendingName:  currentGame.GetDefendingPlayer().Name,
 		GameOver:             currentGame.IsGameOver(),
 		IsDraw:				  currentGame.IsDraw(),
+		IsAbandoned:          currentGame.IsAbandoned(),
 		LosingPlayerName:	  currentGame.GetLosingPlayerName(),
+		LosingTeam:           currentGame.GetLosingTeamPlayerNames(),
+		LosingPlayerFinalHand: currentGame.GetLosingPlayerFinalHand(),
+		LosingHands:          currentGame.GetLosingFinalHands(),
+		IsPogony:             currentGame.IsPogony(),
+		MatchScores:          gameHolder.GetMatchScores(),
+		Phase:                currentGame.GetPhase(),
+		DeckOrder:            currentGame.GetRevealedDeckOrder(),
+		DeckSalt:             currentGame.GetRevealedDeckSalt(),
 	}
 
 	return resp
 }
 
-func getUpdateTurnResponse() httpPayloadTypes.JSONResponseData {
+func getUpdateTurnResponse(gameHolder *GameHolder) httpPayloadTypes.JSONResponseData {
+	currentGame := gameHolder.game
 	resp := &httpPayloadTypes.TurnUpdateResponse{
 		PlayerCards: currentGame.GetPlayersCardsMap(),
 		CardsOnTable: currentGame.GetCardsOnBoard(),
+		PlayerStartingName: currentGame.GetStartingPlayer().Name,
+		PlayerDefendingName: currentGame.GetDefendingPlayer().Name,
+		PlayerTransferringName: currentGame.GetTransferringPlayerName(),
+		Phase: currentGame.GetPhase(),
+		PassedPlayerNames: currentGame.GetPassedPlayerNames(),
+	}
+
+	return resp
+}
+
+func getUpdateAfterMoveResponse(gameHolder *GameHolder) httpPayloadTypes.JSONResponseData {
+	// Some moves end the bout (like the last pass after defender declared taking)
+	currentGame := gameHolder.game
+	if len(currentGame.GetCardsOnBoard()) == 0 {
+		return getUpdateGameResponse(gameHolder)
+	}
+	return getUpdateTurnResponse(gameHolder)
+}
+
+func getTakeDeclaredResponse(gameHolder *GameHolder) httpPayloadTypes.JSONResponseData {
+	currentGame := gameHolder.game
+	resp := &httpPayloadTypes.TakeDeclaredResponse{
+		PlayerDefendingName: currentGame.GetDefendingPlayer().Name,
+		CardsOnTable:        currentGame.GetCardsOnBoard(),
+		Phase:               currentGame.GetPhase(),
 	}
 
 	return resp
 }
 
-func getStartGameResponse() httpPayloadTypes.JSONResponseData {
+func getRedealResponse(gameHolder *GameHolder, requestingPlayerName string) httpPayloadTypes.JSONResponseData {
+	currentGame := gameHolder.game
+	resp := &httpPayloadTypes.RedealResponse{
+		PlayerCards:          currentGame.GetPlayersCardsMap(),
+		KozerCard:            currentGame.KozerCard,
+		NumOfCardsLeftInDeck: currentGame.GetNumOfCardsLeftInDeck(),
+		PlayerStartingName:   currentGame.GetStartingPlayer().Name,
+		PlayerDefendingName:  currentGame.GetDefendingPlayer().Name,
+		RequestingPlayerName: requestingPlayerName,
+		RedealPlayerNames:    currentGame.GetRedealPlayerNames(),
+		DeckCommitment:       currentGame.GetDeckCommitment(),
+	}
+
+	return resp
+}
+
+func getKozerSwappedResponse(gameHolder *GameHolder, playerName string) httpPayloadTypes.JSONResponseData {
+	currentGame := gameHolder.game
+	resp := &httpPayloadTypes.KozerSwappedResponse{
+		PlayerCards: currentGame.GetPlayersCardsMap(),
+		KozerCard:   currentGame.KozerCard,
+		PlayerName:  playerName,
+	}
+
+	return resp
+}
+
+func getChallengeResponse(challengerName string, cheaterName string) httpPayloadTypes.JSONResponseData {
+	resp := &httpPayloadTypes.ChallengeResponse{
+		ChallengerName: challengerName,
+		CheaterName:    cheaterName,
+		IsSuccessful:   cheaterName != "",
+	}
+
+	return resp
+}
+
+func getLegalMovesResponse(gameHolder *GameHolder, player *game.Player) httpPayloadTypes.JSONResponseData {
+	currentGame := gameHolder.game
+	resp := &httpPayloadTypes.LegalMovesResponse{
+		Moves: currentGame.LegalMoves(player),
+	}
+
+	return resp
+}
+
+func getStartGameResponse(gameHolder *GameHolder) httpPayloadTypes.JSONResponseData {
+	currentGame := gameHolder.game
 	resp := &httpPayloadTypes.StartGameResponse{
 		PlayerCards: currentGame.GetPlayersCardsMap(),
 		KozerCard: currentGame.KozerCard,
@@ -35,6 +121,10 @@ func getStartGameResponse() httpPayloadTypes.JSONResponseData {
 		PlayerDefendingName: currentGame.GetDefendingPlayer().Name,
 		CardsOnTable:         currentGame.GetCardsOnBoard(),
 		Players:			currentGame.GetPlayerNamesArray(),
+		Options:            getPublicGameOptions(currentGame.GetOptions()),
+		PlayerTeams:        currentGame.GetPlayerTeamsMap(),
+		RedealPlayerNames:  currentGame.GetRedealPlayerNames(),
+		DeckCommitment:     currentGame.GetDeckCommitment(),
 	}
 
 	return resp
@@ -48,7 +138,8 @@ func getGameStatusResponse() httpPayloadTypes.JSONResponseData {
 	return resp
 }
 
-func getGameRestartResponse() httpPayloadTypes.JSONResponseData {
+func getGameRestartResponse(gameHolder *GameHolder) httpPayloadTypes.JSONResponseData {
+	currentGame := gameHolder.game
 	resp := &httpPayloadTypes.GameRestartResponse{
 		PlayerCards:          currentGame.GetPlayersCardsMap(),
 		KozerCard:            currentGame.KozerCard,
@@ -58,11 +149,20 @@ func getGameRestartResponse() httpPayloadTypes.JSONResponseData {
 		CardsOnTable:         currentGame.GetCardsOnBoard(),
 		GameOver:             currentGame.IsGameOver(),
 		IsDraw:				  currentGame.IsDraw(),
+		Options:              getPublicGameOptions(currentGame.GetOptions()),
+		DeckCommitment:       currentGame.GetDeckCommitment(),
 	}
 
 	return resp
 }
 
+func getPublicGameOptions(options *game.GameOptions) *game.GameOptions {
+	// Copy of game options without the seed, which would reveal the deck order
+	publicOptions := *options
+	publicOptions.Seed = 0
+	return &publicOptions
+}
+
 func getGetConnectionIdResponse(user *User) httpPayloadTypes.JSONResponseData {
 	resp := &httpPayloadTypes.GetConnectionIdResponse{
 		ConnectionId: user.connectionId,
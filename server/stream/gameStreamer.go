@@ -50,7 +50,12 @@ func (this *GameStreamer) StreamLoop(w *http.ResponseWriter, messageChan chan ht
 
 	for {
 		select {
-			case originalData := <-messageChan:
+			case originalData, ok := <-messageChan:
+				if !ok {
+					output.Spit("client is too slow, closing connection to game streamer")
+					return
+				}
+
 				customizedData, err := customizeDataFunc(originalData)
 				if err != nil {
 					http.Error(*w, "Problem writing data to event", http.StatusInternalServerError)
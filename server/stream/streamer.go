@@ -8,6 +8,10 @@ import (
 	"reflect"
 )
 
+// Events are buffered per client, a client that falls this far behind is disconnected
+// so publishing never waits for a stuck connection. Browsers reconnect on their own.
+const clientBufferSize = 64
+
 type SSEStreamer struct {
 	// Events are pushed to this channel by the main events-gathering routine
 	Notifier chan httpPayloadTypes.JSONResponseData
@@ -24,7 +28,7 @@ type SSEStreamer struct {
 
 func NewSSEStreamer() (streamer *SSEStreamer) {
 	streamer = &SSEStreamer{
-		Notifier:       make(chan httpPayloadTypes.JSONResponseData),
+		Notifier:       make(chan httpPayloadTypes.JSONResponseData, clientBufferSize),
 		newClients:     make(chan chan httpPayloadTypes.JSONResponseData),
 		closingClients: make(chan chan httpPayloadTypes.JSONResponseData),
 		clients:        make(map[chan httpPayloadTypes.JSONResponseData]bool),
@@ -40,7 +44,7 @@ func (this *SSEStreamer) RegisterClient(w *http.ResponseWriter) chan httpPayload
 	this.addHeaders(w)
 
 	// New client channels
-	messageChan := make(chan httpPayloadTypes.JSONResponseData)
+	messageChan := make(chan httpPayloadTypes.JSONResponseData, clientBufferSize)
 	this.newClients <- messageChan
 
 	return messageChan
@@ -64,7 +68,11 @@ func (this *SSEStreamer) StreamLoop(w *http.ResponseWriter, messageChan chan htt
 
 	for {
 		select {
-			case s := <-messageChan:
+			case s, ok := <-messageChan:
+				if !ok {
+					output.Spit("Client is too slow, closing connection to streamer")
+					return
+				}
 				if _, err := fmt.Fprintf(*w, "%s", convertToString(s)); err != nil {
 					http.Error(*w, "Problem writing data to event", http.StatusInternalServerError)
 					return
@@ -108,7 +116,13 @@ func (this *SSEStreamer) listen() {
 				}
 
 				for clientMessageChan := range this.clients {
-					clientMessageChan <- event
+					select {
+						case clientMessageChan <- event:
+						default:
+							// Client is stuck, its stream loop ends once the channel is closed
+							delete(this.clients, clientMessageChan)
+							close(clientMessageChan)
+					}
 				}
 		}
 	}
@@ -50,6 +50,22 @@ func getEventName(obj httpPayloadTypes.JSONResponseData) string {
 		return "gameupdated"
 	}
 
+	if _, ok := obj.(*httpPayloadTypes.TakeDeclaredResponse); ok {
+		return "takedeclared"
+	}
+
+	if _, ok := obj.(*httpPayloadTypes.RedealResponse); ok {
+		return "redeal"
+	}
+
+	if _, ok := obj.(*httpPayloadTypes.KozerSwappedResponse); ok {
+		return "kozerswapped"
+	}
+
+	if _, ok := obj.(*httpPayloadTypes.ChallengeResponse); ok {
+		return "challenge"
+	}
+
 	if _, ok := obj.(*httpPayloadTypes.IsAliveResponse); ok {
 		return "isAlive"
 	}
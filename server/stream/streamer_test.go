@@ -0,0 +1,38 @@
+package stream
+
+import (
+	"DurakGo/server/httpPayloadTypes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPublishDoesNotWaitForStuckClient(t *testing.T) {
+	streamer := NewSSEStreamer()
+	var w http.ResponseWriter = httptest.NewRecorder()
+	messageChan := streamer.RegisterClient(&w)
+
+	published := make(chan bool)
+	go func() {
+		for i := 0; i < clientBufferSize*4; i++ {
+			streamer.Publish(&httpPayloadTypes.GameStatusResponse{})
+		}
+		published <- true
+	}()
+
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing waits for a client that does not read")
+	}
+
+	// Buffered events are still delivered before the client is disconnected
+	numOfEvents := 0
+	for range messageChan {
+		numOfEvents++
+	}
+	if numOfEvents != clientBufferSize {
+		t.Fatalf("expected %d buffered events, got %d", clientBufferSize, numOfEvents)
+	}
+}
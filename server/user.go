@@ -4,6 +4,7 @@ import (
 	"DurakGo/output"
 	"DurakGo/server/httpPayloadTypes"
 	"fmt"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,23 +15,24 @@ type User struct {
 	gameChan     chan httpPayloadTypes.JSONResponseData
 	appChan      chan httpPayloadTypes.JSONResponseData
 	name         string
-	lastAlive    int64
+	lastAlive    int64  // Unix time, updated by requests while checkIsAlive reads it
 	notAliveChan chan *User
 	isJoined     bool
 }
 
 func (this *User) receivedAlive() {
-	this.lastAlive = time.Now().Unix()
+	atomic.StoreInt64(&this.lastAlive, time.Now().Unix())
 }
 
 func (this *User) checkIsAlive(ttl int) {
-	output.Spit(fmt.Sprintf("go routine - monitoring if user %s is alive - start", this))
+	// Name is set once the user joins a game, so only the connection id is logged here
+	output.Spit(fmt.Sprintf("go routine - monitoring if user %s is alive - start", this.connectionId))
 	defer func() {
-		output.Spit(fmt.Sprintf("go routine - monitoring if user %s is alive - ended", this))
+		output.Spit(fmt.Sprintf("go routine - monitoring if user %s is alive - ended", this.connectionId))
 	}()
 	for {
 		now := time.Now().Unix()
-		if now - this.lastAlive > int64(ttl) {
+		if now - atomic.LoadInt64(&this.lastAlive) > int64(ttl) {
 			this.notAliveChan <- this
 			return
 		}
@@ -4,10 +4,12 @@ import (
 	"DurakGo/output"
 	"fmt"
 	"math/rand"
+	"sync"
 )
 
 type UserManager struct {
 	users []*User
+	usersLock *sync.Mutex  // Users are created and looked up by concurrent requests
 	notAliveChan chan *User
 	ttl int
 }
@@ -17,16 +19,21 @@ func NewUserManager(ttl int) *UserManager {
 	return &UserManager{
 		notAliveChan: make(chan *User),
 		users: make([]*User, 0),
+		usersLock: &sync.Mutex{},
 		ttl: ttl,
 	}
 }
 
 
 func (this *UserManager) CreateNewUser() *User {
+	this.usersLock.Lock()
 	u := &User{connectionId: this.createUserIdentificationString(), notAliveChan: this.notAliveChan,
 		isJoined: false, gameChan:nil, appChan: nil}
-	output.Spit(fmt.Sprintf("New User Created: %s", u))
 	u.receivedAlive()
+	this.users = append(this.users, u)
+	this.usersLock.Unlock()
+
+	output.Spit(fmt.Sprintf("New User Created: %s", u))
 	go u.checkIsAlive(this.ttl)
 	return u
 }
@@ -60,6 +67,9 @@ func (this *UserManager) createUserIdentificationString() string {
 }
 
 func (this *UserManager) GetUserByConnectionId(connId string) *User {
+	this.usersLock.Lock()
+	defer func() { this.usersLock.Unlock() }()
+
 	for _, u := range this.users {
 		if u.connectionId == connId {
 			return u